@@ -0,0 +1,88 @@
+package clerk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+const clerkActor = key("clerkActor")
+
+// ContextWithActor returns a new context which includes the actor
+// of an impersonated session.
+func ContextWithActor(ctx context.Context, actor *ActorClaims) context.Context {
+	return context.WithValue(ctx, clerkActor, actor)
+}
+
+// ActorFromContext returns the actor of the impersonated session
+// from the context. It returns false if the session is not
+// impersonated.
+func ActorFromContext(ctx context.Context) (*ActorClaims, bool) {
+	actor, ok := ctx.Value(clerkActor).(*ActorClaims)
+	return actor, ok && actor != nil
+}
+
+// ActorClaims describes the actor of an impersonated session, as
+// found in the 'act' claim of session tokens, or in sessions and
+// actor tokens. Use the ActorClaims methods of Claims, Session and
+// ActorToken to decode it.
+// Actors can be nested when an impersonated session impersonates
+// another user. The nested Actor is the one that started the
+// previous impersonation.
+type ActorClaims struct {
+	// Subject is the ID of the user that impersonates the session
+	// user.
+	Subject string `json:"sub"`
+	// Issuer is the origin that issued the impersonation, like the
+	// Clerk Dashboard.
+	Issuer string `json:"iss,omitempty"`
+	// SessionID is the ID of the actor's own session.
+	SessionID string `json:"sid,omitempty"`
+	// Actor holds any nested actor.
+	Actor *ActorClaims `json:"act,omitempty"`
+}
+
+// Subjects returns the IDs of all the actors in the chain, starting
+// with this one.
+func (a *ActorClaims) Subjects() []string {
+	var subjects []string
+	for actor := a; actor != nil; actor = actor.Actor {
+		subjects = append(subjects, actor.Subject)
+	}
+	return subjects
+}
+
+// ActorClaims decodes the 'act' claim. It returns nil if the session
+// is not impersonated.
+func (c *Claims) ActorClaims() (*ActorClaims, error) {
+	return parseActorClaims(c.Actor)
+}
+
+// ActorClaims decodes the actor of the session. It returns nil if the
+// session is not impersonated.
+func (s *Session) ActorClaims() (*ActorClaims, error) {
+	return parseActorClaims(s.Actor)
+}
+
+// ActorClaims decodes the actor of the actor token.
+func (t *ActorToken) ActorClaims() (*ActorClaims, error) {
+	return parseActorClaims(t.Actor)
+}
+
+// Checks if the raw actor is set.
+func hasActor(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && !bytes.Equal(raw, []byte("null"))
+}
+
+func parseActorClaims(raw json.RawMessage) (*ActorClaims, error) {
+	if !hasActor(raw) {
+		return nil, nil
+	}
+	actor := &ActorClaims{}
+	err := json.Unmarshal(raw, actor)
+	if err != nil {
+		return nil, err
+	}
+	return actor, nil
+}
@@ -0,0 +1,64 @@
+package clerk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActorClaims(t *testing.T) {
+	t.Parallel()
+	session := &Session{}
+	err := json.Unmarshal([]byte(`{"id":"sess_123","actor":{"sub":"user_456","act":{"sub":"user_789"}}}`), session)
+	require.NoError(t, err)
+	actor, err := session.ActorClaims()
+	require.NoError(t, err)
+	require.Equal(t, "user_456", actor.Subject)
+	require.Equal(t, []string{"user_456", "user_789"}, actor.Subjects())
+
+	// Fields that ActorClaims doesn't describe are kept in the raw
+	// actor.
+	actorToken := &ActorToken{}
+	err = json.Unmarshal([]byte(`{"id":"act_123","actor":{"sub":"user_456","reason":"support"}}`), actorToken)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"sub":"user_456","reason":"support"}`, string(actorToken.Actor))
+	actor, err = actorToken.ActorClaims()
+	require.NoError(t, err)
+	require.Equal(t, "user_456", actor.Subject)
+
+	claims := &SessionClaims{}
+	err = json.Unmarshal([]byte(`{"sub":"user_123","sid":"sess_123"}`), claims)
+	require.NoError(t, err)
+	require.False(t, claims.IsImpersonated())
+	actor, err = claims.ActorClaims()
+	require.NoError(t, err)
+	require.Nil(t, actor)
+	err = json.Unmarshal([]byte(`{"sub":"user_123","act":{"sub":"user_456","sid":"sess_456"}}`), claims)
+	require.NoError(t, err)
+	require.True(t, claims.IsImpersonated())
+	actor, err = claims.ActorClaims()
+	require.NoError(t, err)
+	require.Equal(t, "sess_456", actor.SessionID)
+
+	// Actors that are not objects can't be decoded.
+	claims = &SessionClaims{}
+	err = json.Unmarshal([]byte(`{"sub":"user_123","act":"user_456"}`), claims)
+	require.NoError(t, err)
+	require.True(t, claims.IsImpersonated())
+	_, err = claims.ActorClaims()
+	require.Error(t, err)
+}
+
+func TestActorFromContext(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, ok := ActorFromContext(ctx)
+	require.False(t, ok)
+
+	actor := &ActorClaims{Subject: "user_456"}
+	got, ok := ActorFromContext(ContextWithActor(ctx, actor))
+	require.True(t, ok)
+	require.Equal(t, actor, got)
+}
@@ -9,17 +9,21 @@ import (
 )
 
 // Create creates a new actor token.
-func Create(ctx context.Context, params *CreateParams) (*clerk.ActorToken, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.ActorToken, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Revoke revokes a pending actor token.
-func Revoke(ctx context.Context, id string) (*clerk.ActorToken, error) {
-	return getClient().Revoke(ctx, id)
+func Revoke(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.ActorToken, error) {
+	return getClient(ctx).Revoke(ctx, id, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -33,8 +33,8 @@ type CreateParams struct {
 }
 
 // Create creates a new actor token.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.ActorToken, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.ActorToken, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	token := &clerk.ActorToken{}
 	err := c.Backend.Call(ctx, req, token)
@@ -42,13 +42,13 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Actor
 }
 
 // Revoke revokes a pending actor token.
-func (c *Client) Revoke(ctx context.Context, id string) (*clerk.ActorToken, error) {
+func (c *Client) Revoke(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.ActorToken, error) {
 	token := &clerk.ActorToken{}
 	path, err := clerk.JoinPath(path, id, "revoke")
 	if err != nil {
 		return token, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	err = c.Backend.Call(ctx, req, token)
 	return token, err
 }
@@ -9,22 +9,26 @@ import (
 )
 
 // Create adds a new identifier to the allowlist.
-func Create(ctx context.Context, params *CreateParams) (*clerk.AllowlistIdentifier, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.AllowlistIdentifier, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Delete removes an identifier from the allowlist.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
 // List returns all the identifiers in the allowlist.
-func List(ctx context.Context, params *ListParams) (*clerk.AllowlistIdentifierList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.AllowlistIdentifierList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
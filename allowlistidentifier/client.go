@@ -31,8 +31,8 @@ type CreateParams struct {
 }
 
 // Create adds a new identifier to the allowlist.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.AllowlistIdentifier, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.AllowlistIdentifier, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	identifier := &clerk.AllowlistIdentifier{}
 	err := c.Backend.Call(ctx, req, identifier)
@@ -40,12 +40,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Allow
 }
 
 // Delete removes an identifier from the allowlist.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	identifier := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, identifier)
 	return identifier, err
@@ -56,8 +56,8 @@ type ListParams struct {
 }
 
 // List returns all the identifiers in the allowlist.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.AllowlistIdentifierList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path))
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.AllowlistIdentifierList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path), opts...)
 	list := &clerk.AllowlistIdentifierList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
@@ -0,0 +1,136 @@
+// Package api provides a single entry point to all Clerk Backend API
+// resources.
+package api
+
+import (
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/actortoken"
+	"github.com/clerk/clerk-sdk-go/v2/allowlistidentifier"
+	"github.com/clerk/clerk-sdk-go/v2/blocklistidentifier"
+	"github.com/clerk/clerk-sdk-go/v2/client"
+	"github.com/clerk/clerk-sdk-go/v2/domain"
+	"github.com/clerk/clerk-sdk-go/v2/emailaddress"
+	clerkhttp "github.com/clerk/clerk-sdk-go/v2/http"
+	"github.com/clerk/clerk-sdk-go/v2/instancesettings"
+	"github.com/clerk/clerk-sdk-go/v2/invitation"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/clerk/clerk-sdk-go/v2/jwttemplate"
+	"github.com/clerk/clerk-sdk-go/v2/organization"
+	"github.com/clerk/clerk-sdk-go/v2/organizationdomain"
+	"github.com/clerk/clerk-sdk-go/v2/organizationinvitation"
+	"github.com/clerk/clerk-sdk-go/v2/organizationmembership"
+	"github.com/clerk/clerk-sdk-go/v2/phonenumber"
+	"github.com/clerk/clerk-sdk-go/v2/proxycheck"
+	"github.com/clerk/clerk-sdk-go/v2/redirecturl"
+	"github.com/clerk/clerk-sdk-go/v2/samlconnection"
+	"github.com/clerk/clerk-sdk-go/v2/session"
+	"github.com/clerk/clerk-sdk-go/v2/signintoken"
+	"github.com/clerk/clerk-sdk-go/v2/svixwebhook"
+	"github.com/clerk/clerk-sdk-go/v2/template"
+	"github.com/clerk/clerk-sdk-go/v2/testingtoken"
+	"github.com/clerk/clerk-sdk-go/v2/user"
+)
+
+// API holds a client for every Clerk Backend API resource. All
+// clients share the same Backend.
+//
+//	clerkAPI := api.New(&clerk.ClientConfig{
+//		BackendConfig: clerk.BackendConfig{
+//			Key: clerk.String("sk_live_XXX"),
+//		},
+//	})
+//	usr, err := clerkAPI.Users.Get(ctx, "user_123")
+type API struct {
+	// Backend is the Backend that all resource clients use.
+	Backend clerk.Backend
+	// JWKSCache caches the JSON Web Key Set, which is fetched with
+	// the JWKS client. Share it between the http middleware, with the
+	// JWKSCache option, and calls to jwt.Verify.
+	JWKSCache *jwks.Cache
+	// LoaderCache keeps the users and organizations that the
+	// http.WithLoaders middleware fetches, so that they can be shared
+	// across requests.
+	LoaderCache *clerkhttp.LoaderCache
+
+	ActorTokens             *actortoken.Client
+	AllowlistIdentifiers    *allowlistidentifier.Client
+	BlocklistIdentifiers    *blocklistidentifier.Client
+	Clients                 *client.Client
+	Domains                 *domain.Client
+	EmailAddresses          *emailaddress.Client
+	InstanceSettings        *instancesettings.Client
+	Invitations             *invitation.Client
+	JWKS                    *jwks.Client
+	JWTTemplates            *jwttemplate.Client
+	Organizations           *organization.Client
+	OrganizationDomains     *organizationdomain.Client
+	OrganizationInvitations *organizationinvitation.Client
+	OrganizationMemberships *organizationmembership.Client
+	PhoneNumbers            *phonenumber.Client
+	ProxyChecks             *proxycheck.Client
+	RedirectURLs            *redirecturl.Client
+	SAMLConnections         *samlconnection.Client
+	Sessions                *session.Client
+	SignInTokens            *signintoken.Client
+	SvixWebhooks            *svixwebhook.Client
+	Templates               *template.Client
+	TestingTokens           *testingtoken.Client
+	Users                   *user.Client
+}
+
+// New creates a Backend from the provided configuration and returns
+// an API with clients for all resources. Options like retries,
+// logging and middleware are configured once, in the
+// ClientConfig.
+func New(config *clerk.ClientConfig) *API {
+	if config == nil {
+		config = &clerk.ClientConfig{}
+	}
+	return NewWithBackend(clerk.NewBackend(&config.BackendConfig))
+}
+
+// NewWithBackend returns an API with clients for all resources that
+// use the provided Backend. The caches use their default
+// configuration.
+func NewWithBackend(backend clerk.Backend) *API {
+	jwksClient := &jwks.Client{Backend: backend}
+	return &API{
+		Backend:                 backend,
+		JWKSCache:               jwks.NewCache(&jwks.CacheConfig{Client: jwksClient}),
+		LoaderCache:             clerkhttp.NewLoaderCache(nil),
+		ActorTokens:             &actortoken.Client{Backend: backend},
+		AllowlistIdentifiers:    &allowlistidentifier.Client{Backend: backend},
+		BlocklistIdentifiers:    &blocklistidentifier.Client{Backend: backend},
+		Clients:                 &client.Client{Backend: backend},
+		Domains:                 &domain.Client{Backend: backend},
+		EmailAddresses:          &emailaddress.Client{Backend: backend},
+		InstanceSettings:        &instancesettings.Client{Backend: backend},
+		Invitations:             &invitation.Client{Backend: backend},
+		JWKS:                    jwksClient,
+		JWTTemplates:            &jwttemplate.Client{Backend: backend},
+		Organizations:           &organization.Client{Backend: backend},
+		OrganizationDomains:     &organizationdomain.Client{Backend: backend},
+		OrganizationInvitations: &organizationinvitation.Client{Backend: backend},
+		OrganizationMemberships: &organizationmembership.Client{Backend: backend},
+		PhoneNumbers:            &phonenumber.Client{Backend: backend},
+		ProxyChecks:             &proxycheck.Client{Backend: backend},
+		RedirectURLs:            &redirecturl.Client{Backend: backend},
+		SAMLConnections:         &samlconnection.Client{Backend: backend},
+		Sessions:                &session.Client{Backend: backend},
+		SignInTokens:            &signintoken.Client{Backend: backend},
+		SvixWebhooks:            &svixwebhook.Client{Backend: backend},
+		Templates:               &template.Client{Backend: backend},
+		TestingTokens:           &testingtoken.Client{Backend: backend},
+		Users:                   &user.Client{Backend: backend},
+	}
+}
+
+// NewFromEnv returns an API that is configured from the environment.
+// See clerk.ConfigFromEnv for details.
+func NewFromEnv() (*API, error) {
+	backend, err := clerk.NewBackendFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewWithBackend(backend), nil
+}
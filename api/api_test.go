@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = &http.Client{
+		Transport: &clerktest.RoundTripper{
+			T:      t,
+			Out:    json.RawMessage(`{"id":"user_123"}`),
+			Method: http.MethodGet,
+			Path:   "/v1/users/user_123",
+		},
+	}
+	clerkAPI := New(config)
+	require.NotNil(t, clerkAPI.Backend)
+
+	require.NotNil(t, clerkAPI.JWKSCache)
+	require.NotNil(t, clerkAPI.LoaderCache)
+
+	// All resource clients are set and share the same Backend.
+	v := reflect.ValueOf(clerkAPI).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Elem().Kind() != reflect.Struct || !field.Elem().FieldByName("Backend").IsValid() {
+			continue
+		}
+		name := v.Type().Field(i).Name
+		require.False(t, field.IsNil(), "%s client is not set", name)
+		require.Equal(t, clerkAPI.Backend, field.Elem().FieldByName("Backend").Interface(), "%s client uses a different Backend", name)
+	}
+
+	usr, err := clerkAPI.Users.Get(context.Background(), "user_123")
+	require.NoError(t, err)
+	require.Equal(t, "user_123", usr.ID)
+}
+
+func TestNew_NilConfig(t *testing.T) {
+	t.Parallel()
+	clerkAPI := New(nil)
+	require.NotNil(t, clerkAPI.Backend)
+	require.NotNil(t, clerkAPI.Users)
+}
+
+func TestNew_JWKSCache(t *testing.T) {
+	t.Parallel()
+	totalRequests := 0
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = &http.Client{
+		Transport: &clerktest.RoundTripper{
+			T:      t,
+			Out:    json.RawMessage(`{"keys":[{"kid":"ins_123","kty":"oct","k":"c2VjcmV0"}]}`),
+			Method: http.MethodGet,
+			Path:   "/v1/jwks",
+		},
+	}
+	config.HTTPClient.Transport = countingTransport{config.HTTPClient.Transport, &totalRequests}
+	clerkAPI := New(config)
+
+	// The cache fetches the key set with the API's Backend.
+	for i := 0; i < 2; i++ {
+		key, err := clerkAPI.JWKSCache.Get(context.Background(), "ins_123")
+		require.NoError(t, err)
+		require.Equal(t, "ins_123", key.KeyID)
+	}
+	require.Equal(t, 1, totalRequests)
+}
+
+type countingTransport struct {
+	http.RoundTripper
+	count *int
+}
+
+func (t countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	*t.count++
+	return t.RoundTripper.RoundTrip(r)
+}
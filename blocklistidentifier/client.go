@@ -30,8 +30,8 @@ type CreateParams struct {
 }
 
 // Create adds a new identifier to the blocklist.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.BlocklistIdentifier, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.BlocklistIdentifier, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	identifier := &clerk.BlocklistIdentifier{}
 	err := c.Backend.Call(ctx, req, identifier)
@@ -39,12 +39,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Block
 }
 
 // Delete removes an identifier from the blocklist.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	identifier := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, identifier)
 	return identifier, err
@@ -55,8 +55,8 @@ type ListParams struct {
 }
 
 // List returns all the identifiers in the blocklist.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.BlocklistIdentifierList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path))
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.BlocklistIdentifierList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path), opts...)
 	list := &clerk.BlocklistIdentifierList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
@@ -6,10 +6,14 @@ package clerk
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -84,6 +88,9 @@ type APIResponse struct {
 	// response.
 	// Useful for debugging purposes.
 	TraceID string
+	// IdempotencyKey is the idempotency key that was sent with the
+	// request, if any.
+	IdempotencyKey string
 	// RawJSON contains the response body as raw bytes.
 	RawJSON json.RawMessage
 }
@@ -97,21 +104,34 @@ func (resp *APIResponse) Success() bool {
 // NewAPIResponse creates an APIResponse from the passed http.Response
 // and the raw response body.
 func NewAPIResponse(resp *http.Response, body json.RawMessage) *APIResponse {
-	return &APIResponse{
+	apiResponse := &APIResponse{
 		Header:     resp.Header,
 		TraceID:    resp.Header.Get("Clerk-Trace-Id"),
 		Status:     resp.Status,
 		StatusCode: resp.StatusCode,
 		RawJSON:    body,
 	}
+	if resp.Request != nil {
+		apiResponse.IdempotencyKey = resp.Request.Header.Get(idempotencyKeyHeader)
+	}
+	return apiResponse
 }
 
 // APIRequest describes requests to the Clerk API.
 type APIRequest struct {
-	Method      string
-	Path        string
-	Params      Params
-	isMultipart bool
+	Method string
+	Path   string
+	Params Params
+	// IdempotencyKey will be sent with the request, so that the
+	// request can be safely retried. If it's not set, a key will
+	// be generated for POST, PATCH and DELETE requests.
+	IdempotencyKey string
+	isMultipart    bool
+	// Per-request overrides, set through RequestOption.
+	secretKey  string
+	apiVersion string
+	header     http.Header
+	timeout    time.Duration
 }
 
 // SetParams sets the APIRequest.Params.
@@ -119,24 +139,83 @@ func (req *APIRequest) SetParams(params Params) {
 	req.Params = params
 }
 
+// SetIdempotencyKey sets the APIRequest.IdempotencyKey.
+func (req *APIRequest) SetIdempotencyKey(key string) {
+	req.IdempotencyKey = key
+}
+
 // NewAPIRequest creates an APIRequest with the provided HTTP method
-// and path.
-func NewAPIRequest(method, path string) *APIRequest {
-	return &APIRequest{
+// and path. Any RequestOption will be applied to the APIRequest.
+func NewAPIRequest(method, path string, opts ...RequestOption) *APIRequest {
+	req := &APIRequest{
 		Method: method,
 		Path:   path,
 	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(req)
+		}
+	}
+	return req
 }
 
 // NewMultipartAPIRequest creates an APIRequest with the provided HTTP
 // method and path and marks it as multipart. Multipart requests handle
 // their params differently.
-func NewMultipartAPIRequest(method, path string) *APIRequest {
-	req := NewAPIRequest(method, path)
+func NewMultipartAPIRequest(method, path string, opts ...RequestOption) *APIRequest {
+	req := NewAPIRequest(method, path, opts...)
 	req.isMultipart = true
 	return req
 }
 
+// RequestOption can be used to customize a single API request.
+// All API operations accept request options.
+//
+//	user.Get(ctx, "user_123", clerk.WithSecretKey("sk_live_XXX"))
+type RequestOption func(*APIRequest)
+
+// WithSecretKey sets the Clerk secret key that will be used for
+// the request, instead of the Backend's key.
+func WithSecretKey(key string) RequestOption {
+	return func(req *APIRequest) {
+		req.secretKey = key
+	}
+}
+
+// WithHeader sets a header that will be sent with the request.
+// Existing values for the same header will be replaced.
+func WithHeader(key, value string) RequestOption {
+	return func(req *APIRequest) {
+		if req.header == nil {
+			req.header = http.Header{}
+		}
+		req.header.Set(key, value)
+	}
+}
+
+// WithTimeout sets a timeout for the request. The timeout covers
+// all retries of the request.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(req *APIRequest) {
+		req.timeout = timeout
+	}
+}
+
+// WithIdempotencyKey sets the idempotency key for the request.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(req *APIRequest) {
+		req.IdempotencyKey = key
+	}
+}
+
+// WithAPIVersion sets the Clerk API version that will be used for
+// the request.
+func WithAPIVersion(version string) RequestOption {
+	return func(req *APIRequest) {
+		req.apiVersion = version
+	}
+}
+
 // Backend is the primary interface for communicating with the Clerk
 // API.
 type Backend interface {
@@ -187,6 +266,19 @@ type BackendConfig struct {
 	// headers that will be added to every HTTP request that the Backend
 	// does.
 	CustomRequestHeaders *CustomRequestHeaders
+	// Retry configures how failed requests are retried. If it's not
+	// set, requests will not be retried.
+	Retry *RetryConfig
+	// Logger will be used to log every request that the Backend
+	// makes to the Clerk API, including retries.
+	// Secret keys and sensitive fields are redacted from the log
+	// records. Request and response bodies are only logged with
+	// slog.LevelDebug.
+	Logger *slog.Logger
+	// Middleware wraps every API request that the Backend makes.
+	// The first Middleware in the list is the outermost one, which
+	// means that it gets called first.
+	Middleware []Middleware
 }
 
 // NewBackend returns a default backend implementation with the
@@ -203,12 +295,20 @@ func NewBackend(config *BackendConfig) Backend {
 	if config.Key == nil {
 		config.Key = String(secretKey)
 	}
-	return &defaultBackend{
+	var retry *RetryConfig
+	if config.Retry != nil {
+		retry = config.Retry.withDefaults()
+	}
+	b := &defaultBackend{
 		HTTPClient:           config.HTTPClient,
 		URL:                  *config.URL,
 		Key:                  *config.Key,
 		CustomRequestHeaders: config.CustomRequestHeaders,
+		Retry:                retry,
+		Logger:               config.Logger,
 	}
+	b.handler = chainMiddleware(b.do, config.Middleware...)
+	return b
 }
 
 // GetBackend returns the library's supported backend for the Clerk
@@ -239,15 +339,63 @@ func SetBackend(b Backend) {
 	backend.Backend = b
 }
 
+const clerkBackend = key("clerkBackend")
+
+// ContextWithBackend returns a new context which includes the
+// provided Backend.
+// API operations that are invoked without a client will use the
+// Backend from the context, instead of the package level Backend.
+// Useful for services that communicate with more than one Clerk
+// instance.
+//
+//	ctx = clerk.ContextWithBackend(ctx, clerk.NewBackend(&clerk.BackendConfig{
+//		Key: clerk.String("sk_live_XXX"),
+//	}))
+//	usr, err := user.Get(ctx, "user_123")
+func ContextWithBackend(ctx context.Context, b Backend) context.Context {
+	return context.WithValue(ctx, clerkBackend, b)
+}
+
+// BackendIdentity returns a string that identifies the Clerk instance
+// that the Backend sends requests to. It's derived from the API URL
+// and the secret key, so Backends with the same configuration have
+// the same identity. It returns false for Backends that were not
+// created with NewBackend.
+func BackendIdentity(b Backend) (string, bool) {
+	db, ok := b.(*defaultBackend)
+	if !ok || db == nil {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(db.URL + "\x00" + db.Key))
+	return hex.EncodeToString(sum[:]), true
+}
+
+// BackendFromContext returns the Backend from the context, if one
+// was set with ContextWithBackend.
+func BackendFromContext(ctx context.Context) (Backend, bool) {
+	b, ok := ctx.Value(clerkBackend).(Backend)
+	return b, ok && b != nil
+}
+
 type defaultBackend struct {
 	HTTPClient           *http.Client
 	URL                  string
 	Key                  string
 	CustomRequestHeaders *CustomRequestHeaders
+	Retry                *RetryConfig
+	Logger               *slog.Logger
+	// The handler that sends the request, wrapped with any
+	// configured middleware.
+	handler Handler
 }
 
 // Call sends requests to the Clerk API and handles the responses.
 func (b *defaultBackend) Call(ctx context.Context, apiReq *APIRequest, setter ResponseReader) error {
+	if apiReq.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, apiReq.timeout)
+		defer cancel()
+	}
 	req, err := b.newRequest(ctx, apiReq)
 	if err != nil {
 		return err
@@ -257,7 +405,23 @@ func (b *defaultBackend) Call(ctx context.Context, apiReq *APIRequest, setter Re
 		return err
 	}
 
-	return b.do(req, setter)
+	apiResponse, err := b.handler(req, apiReq)
+	if err != nil {
+		return err
+	}
+	if apiResponse == nil {
+		return errors.New("clerk: no API response")
+	}
+
+	setter.Read(apiResponse)
+	if len(apiResponse.RawJSON) > 0 {
+		err := json.Unmarshal(apiResponse.RawJSON, setter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (b *defaultBackend) newRequest(ctx context.Context, apiReq *APIRequest) (*http.Request, error) {
@@ -269,42 +433,121 @@ func (b *defaultBackend) newRequest(ctx context.Context, apiReq *APIRequest) (*h
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", b.Key))
+	key := b.Key
+	if apiReq.secretKey != "" {
+		key = apiReq.secretKey
+	}
+	apiVersion := clerkAPIVersion
+	if apiReq.apiVersion != "" {
+		apiVersion = apiReq.apiVersion
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", key))
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("User-Agent", fmt.Sprintf("clerk/clerk-sdk-go@%s", sdkVersion))
-	req.Header.Add("Clerk-API-Version", clerkAPIVersion)
+	req.Header.Add("Clerk-API-Version", apiVersion)
 	req.Header.Add("X-Clerk-SDK", fmt.Sprintf("go/%s", sdkVersion))
 	b.CustomRequestHeaders.apply(req)
+	for k, values := range apiReq.header {
+		req.Header[k] = values
+	}
+
+	idempotencyKey, err := getIdempotencyKey(apiReq)
+	if err != nil {
+		return nil, err
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
 
 	return req, nil
 }
 
-func (b *defaultBackend) do(req *http.Request, setter ResponseReader) error {
-	resp, err := b.HTTPClient.Do(req)
+// The header that carries idempotency keys in API requests.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// Returns the idempotency key for the request. If the APIRequest has
+// no key, a new one is generated for mutating requests.
+// The key is stored in the APIRequest, so that it stays the same
+// for all attempts of the request.
+func getIdempotencyKey(apiReq *APIRequest) (string, error) {
+	if apiReq.IdempotencyKey != "" {
+		return apiReq.IdempotencyKey, nil
+	}
+	switch apiReq.Method {
+	case http.MethodPost, http.MethodPatch, http.MethodDelete:
+		idempotencyKey, err := newIdempotencyKey()
+		if err != nil {
+			return "", err
+		}
+		apiReq.IdempotencyKey = idempotencyKey
+		return idempotencyKey, nil
+	}
+	return "", nil
+}
+
+// Generates a random, version 4 UUID to be used as an idempotency
+// key.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	_, err := rand.Read(b[:])
 	if err != nil {
-		return err
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// Sends the request to the Clerk API, retrying if necessary.
+// Non-successful responses are turned into errors.
+func (b *defaultBackend) do(req *http.Request, _ *APIRequest) (*APIResponse, error) {
+	apiResponse, err := b.send(req, 1)
+	for attempt := 1; b.Retry.shouldRetry(req, apiResponse, err, attempt); attempt++ {
+		wait, ok := b.Retry.backoff(apiResponse, attempt)
+		if !ok || !sleep(req.Context(), wait) {
+			break
+		}
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+		apiResponse, err = b.send(req, attempt+1)
 	}
-	resBody, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	apiResponse := NewAPIResponse(resp, resBody)
 	// Looks like something went wrong. Handle the error.
 	if !apiResponse.Success() {
-		return handleError(apiResponse, resBody)
+		return apiResponse, handleError(apiResponse, apiResponse.RawJSON)
 	}
+	return apiResponse, nil
+}
 
-	setter.Read(apiResponse)
-	if len(resBody) > 0 {
-		err := json.Unmarshal(resBody, setter)
-		if err != nil {
-			return err
-		}
-	}
+// Makes a single HTTP request and reads the response body.
+// The attempt argument is the request's attempt number, used for
+// logging.
+func (b *defaultBackend) send(req *http.Request, attempt int) (*APIResponse, error) {
+	start := time.Now()
+	apiResponse, err := b.roundTrip(req)
+	b.logAttempt(req, apiResponse, err, attempt, time.Since(start))
+	return apiResponse, err
+}
 
-	return nil
+func (b *defaultBackend) roundTrip(req *http.Request) (*APIResponse, error) {
+	resp, err := b.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	return NewAPIResponse(resp, resBody), nil
 }
 
 // Sets the APIRequest params in either the request body, or the
@@ -369,7 +612,12 @@ func handleError(resp *APIResponse, body []byte) error {
 	if err != nil || apiError.Errors == nil {
 		// This is probably not an expected API error.
 		// Return the raw server response.
-		return errors.New(string(body))
+		unexpectedErr := &UnexpectedResponseError{
+			HTTPStatusCode: resp.StatusCode,
+			Body:           body,
+		}
+		unexpectedErr.Read(resp)
+		return unexpectedErr
 	}
 	return apiError
 }
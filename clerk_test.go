@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -489,3 +490,141 @@ func TestJoinPath(t *testing.T) {
 	_, err := JoinPath("https://clerk.com", "*%{wontwork$")
 	require.Error(t, err)
 }
+
+func TestBackendCall_IdempotencyKey(t *testing.T) {
+	var idempotencyKey string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+		_, err := w.Write([]byte(`{}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+	})
+	ctx := context.Background()
+
+	// A key is generated for mutating requests.
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodDelete} {
+		resource := &testResource{}
+		req := NewAPIRequest(method, "/resources")
+		err := backend.Call(ctx, req, resource)
+		require.NoError(t, err)
+		assert.NotEmpty(t, idempotencyKey)
+		assert.Equal(t, idempotencyKey, req.IdempotencyKey)
+		assert.Equal(t, idempotencyKey, resource.Response.IdempotencyKey)
+	}
+
+	// Each request gets a different key.
+	previousKey := idempotencyKey
+	err := backend.Call(ctx, NewAPIRequest(http.MethodPost, "/resources"), &testResource{})
+	require.NoError(t, err)
+	assert.NotEqual(t, previousKey, idempotencyKey)
+
+	// No key is generated for GET requests.
+	resource := &testResource{}
+	err = backend.Call(ctx, NewAPIRequest(http.MethodGet, "/resources"), resource)
+	require.NoError(t, err)
+	assert.Empty(t, idempotencyKey)
+	assert.Empty(t, resource.Response.IdempotencyKey)
+
+	// Keys set on the request are sent.
+	req := NewAPIRequest(http.MethodPost, "/resources")
+	req.SetIdempotencyKey("request-key")
+	err = backend.Call(ctx, req, &testResource{})
+	require.NoError(t, err)
+	assert.Equal(t, "request-key", idempotencyKey)
+
+	// Requests that share a context don't share keys.
+	err = backend.Call(ctx, NewAPIRequest(http.MethodPost, "/resources", WithIdempotencyKey("first-key")), &testResource{})
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", idempotencyKey)
+	err = backend.Call(ctx, NewAPIRequest(http.MethodPost, "/resources"), &testResource{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, idempotencyKey)
+	assert.NotEqual(t, "first-key", idempotencyKey)
+}
+
+func TestBackendCall_RequestOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sk_test_override", r.Header.Get("Authorization"))
+		assert.Equal(t, "2024-10-01", r.Header.Get("Clerk-API-Version"))
+		assert.Equal(t, "custom-value", r.Header.Get("X-Custom-Header"))
+		assert.Equal(t, "custom-key", r.Header.Get("Idempotency-Key"))
+		_, err := w.Write([]byte(`{}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Key:        String("sk_test_123"),
+	})
+	req := NewAPIRequest(
+		http.MethodPost,
+		"/resources",
+		WithSecretKey("sk_test_override"),
+		WithAPIVersion("2024-10-01"),
+		WithHeader("X-Custom-Header", "custom-value"),
+		WithIdempotencyKey("custom-key"),
+	)
+	err := backend.Call(context.Background(), req, &testResource{})
+	require.NoError(t, err)
+}
+
+func TestBackendCall_RequestOptions_Timeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+	})
+	req := NewAPIRequest(http.MethodGet, "/resources", WithTimeout(10*time.Millisecond))
+	err := backend.Call(context.Background(), req, &testResource{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestContextWithBackend(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, ok := BackendFromContext(ctx)
+	require.False(t, ok)
+
+	b := NewBackend(&BackendConfig{})
+	got, ok := BackendFromContext(ContextWithBackend(ctx, b))
+	require.True(t, ok)
+	require.Equal(t, b, got)
+
+	_, ok = BackendFromContext(ContextWithBackend(ctx, nil))
+	require.False(t, ok)
+}
+
+func TestBackendIdentity(t *testing.T) {
+	t.Parallel()
+	id, ok := BackendIdentity(NewBackend(&BackendConfig{Key: String("sk_test_123")}))
+	require.True(t, ok)
+	other, ok := BackendIdentity(NewBackend(&BackendConfig{Key: String("sk_test_123")}))
+	require.True(t, ok)
+	require.Equal(t, id, other)
+	require.NotContains(t, id, "sk_test_123")
+
+	other, ok = BackendIdentity(NewBackend(&BackendConfig{Key: String("sk_test_456")}))
+	require.True(t, ok)
+	require.NotEqual(t, id, other)
+	other, ok = BackendIdentity(NewBackend(&BackendConfig{Key: String("sk_test_123"), URL: String("https://example.com")}))
+	require.True(t, ok)
+	require.NotEqual(t, id, other)
+
+	_, ok = BackendIdentity(nil)
+	require.False(t, ok)
+}
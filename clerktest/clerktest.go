@@ -75,12 +75,21 @@ func GenerateJWT(t *testing.T, claims any, kid string) (string, crypto.PublicKey
 	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	require.NoError(t, err)
 
+	token := GenerateJWTWithKey(t, claims, kid, jose.RS256, privKey)
+	return token, privKey.Public()
+}
+
+// GenerateJWTWithKey creates a JSON web token with the provided
+// claims and key ID, signed with the provided algorithm and key.
+func GenerateJWTWithKey(t *testing.T, claims any, kid string, alg jose.SignatureAlgorithm, key any) string {
+	t.Helper()
+
 	signerOpts := &jose.SignerOptions{}
 	signerOpts.WithType("JWT")
 	if kid != "" {
 		signerOpts.WithHeader("kid", kid)
 	}
-	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: privKey}, signerOpts)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, signerOpts)
 	require.NoError(t, err)
 
 	builder := jwt.Signed(signer)
@@ -88,7 +97,7 @@ func GenerateJWT(t *testing.T, claims any, kid string) (string, crypto.PublicKey
 	token, err := builder.CompactSerialize()
 	require.NoError(t, err)
 
-	return token, privKey.Public()
+	return token
 }
 
 // Clock provides a test clock which can be manually advanced through time.
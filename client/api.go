@@ -9,25 +9,36 @@ import (
 )
 
 // Get retrieves the client specified by ID.
-func Get(ctx context.Context, id string) (*clerk.Client, error) {
-	return getClient().Get(ctx, id)
+func Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Client, error) {
+	return getClient(ctx).Get(ctx, id, opts...)
 }
 
 // Verify verifies the Client in the provided JWT.
-func Verify(ctx context.Context, params *VerifyParams) (*clerk.Client, error) {
-	return getClient().Verify(ctx, params)
+func Verify(ctx context.Context, params *VerifyParams, opts ...clerk.RequestOption) (*clerk.Client, error) {
+	return getClient(ctx).Verify(ctx, params, opts...)
 }
 
 // List returns a list of all the clients.
 //
 // Deprecated: The operation is deprecated and will be removed in
 // future versions.
-func List(ctx context.Context, params *ListParams) (*clerk.ClientList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.ClientList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+// ListAll returns an iterator over all clients that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Client] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
+}
+
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
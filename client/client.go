@@ -26,12 +26,12 @@ func NewClient(config *clerk.ClientConfig) *Client {
 }
 
 // Get retrieves the client specified by ID.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.Client, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Client, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	client := &clerk.Client{}
 	err = c.Backend.Call(ctx, req, client)
 	return client, err
@@ -43,12 +43,12 @@ type VerifyParams struct {
 }
 
 // Verify verifies the Client in the provided JWT.
-func (c *Client) Verify(ctx context.Context, params *VerifyParams) (*clerk.Client, error) {
+func (c *Client) Verify(ctx context.Context, params *VerifyParams, opts ...clerk.RequestOption) (*clerk.Client, error) {
 	path, err := clerk.JoinPath(path, "/verify")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	client := &clerk.Client{}
 	err = c.Backend.Call(ctx, req, client)
@@ -68,10 +68,28 @@ func (params *ListParams) ToQuery() url.Values {
 //
 // Deprecated: The operation is deprecated and will be removed in
 // future versions.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.ClientList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.ClientList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	list := &clerk.ClientList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
 }
+
+// ListAll returns an iterator over all clients that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Client] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.Client, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Clients, list.TotalCount, nil
+	})
+}
@@ -127,13 +127,12 @@ func main() {
 const lineStartsWith = "func (c *Client) "
 
 var nameRE = regexp.MustCompile("^\\w+\\(")
-var argsRE = regexp.MustCompile("^.+\\)\\s[\\(e]")
-var returnRE = regexp.MustCompile("^.+\\s{")
 
 // Parse a method definition line and get the name, arguments and
 // return types.
 // The line has the format
 // func (c *Client) MethodName(ctx context.Context, params *Params) (*clerk.Resource, error) {
+// The return types might also be a single type without parentheses.
 // Quick and dirty method but it works :).
 func getFuncVars(line string) funcVars {
 	line = strings.TrimPrefix(line, "func (c *Client) ")
@@ -141,20 +140,33 @@ func getFuncVars(line string) funcVars {
 	name := nameRE.FindString(line)
 	line = strings.TrimPrefix(line, name)
 
-	args := argsRE.FindString(line)
-	args = strings.TrimSuffix(args, ") e")
-	line = strings.TrimPrefix(line, args)
-
-	returnV := returnRE.FindString(line)
-	returnV = strings.TrimSuffix(returnV, " {")
+	// Find the parenthesis that closes the arguments list.
+	depth := 1
+	end := 0
+	for i, r := range line {
+		if r == '(' {
+			depth++
+		} else if r == ')' {
+			depth--
+		}
+		if depth == 0 {
+			end = i
+			break
+		}
+	}
+	args := line[:end]
+	returnV := strings.TrimSpace(strings.TrimSuffix(line[end+1:], "{"))
 
 	name = strings.Trim(name, "(")
-	args = strings.Trim(args, ") (")
-	returnV = strings.Trim(returnV, ")")
 	allArgs := strings.Split(args, ",")
 	params := make([]string, len(allArgs))
 	for i, arg := range allArgs {
-		params[i] = strings.Split(strings.TrimPrefix(arg, " "), " ")[0]
+		parts := strings.Split(strings.TrimPrefix(arg, " "), " ")
+		params[i] = parts[0]
+		// Variadic arguments need to be expanded.
+		if len(parts) > 1 && strings.HasPrefix(parts[1], "...") {
+			params[i] += "..."
+		}
 	}
 
 	return funcVars{
@@ -190,15 +202,19 @@ type funcVars struct {
 }
 
 var funcTempl = template.Must(template.New("").Parse(`
-func {{.FuncName}}({{.FuncArgs}}) ({{.FuncReturn}}) {
-	return getClient().{{.FuncName}}({{.FuncParams}})
+func {{.FuncName}}({{.FuncArgs}}) {{.FuncReturn}} {
+	return getClient(ctx).{{.FuncName}}({{.FuncParams}})
 }
 `))
 
 var footTempl = template.Must(template.New("").Parse(`
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
 `))
@@ -9,27 +9,31 @@ import (
 )
 
 // Create creates a new domain.
-func Create(ctx context.Context, params *CreateParams) (*clerk.Domain, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.Domain, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Update updates a domain's properties.
-func Update(ctx context.Context, id string, params *UpdateParams) (*clerk.Domain, error) {
-	return getClient().Update(ctx, id, params)
+func Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.Domain, error) {
+	return getClient(ctx).Update(ctx, id, params, opts...)
 }
 
 // Delete removes a domain.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
 // List returns a list of domains.
-func List(ctx context.Context, params *ListParams) (*clerk.DomainList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.DomainList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
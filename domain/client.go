@@ -31,8 +31,8 @@ type CreateParams struct {
 }
 
 // Create creates a new domain.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Domain, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.Domain, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 
 	domain := &clerk.Domain{}
@@ -48,12 +48,12 @@ type UpdateParams struct {
 }
 
 // Update updates a domain's properties.
-func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*clerk.Domain, error) {
+func (c *Client) Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.Domain, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 
 	domain := &clerk.Domain{}
@@ -62,12 +62,12 @@ func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*
 }
 
 // Delete removes a domain.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	domain := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, domain)
 	return domain, err
@@ -78,8 +78,8 @@ type ListParams struct {
 }
 
 // List returns a list of domains.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.DomainList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.DomainList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	list := &clerk.DomainList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
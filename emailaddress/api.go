@@ -9,27 +9,31 @@ import (
 )
 
 // Create creates a new email address.
-func Create(ctx context.Context, params *CreateParams) (*clerk.EmailAddress, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.EmailAddress, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Get retrieves an email address.
-func Get(ctx context.Context, id string) (*clerk.EmailAddress, error) {
-	return getClient().Get(ctx, id)
+func Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.EmailAddress, error) {
+	return getClient(ctx).Get(ctx, id, opts...)
 }
 
 // Update updates the email address specified by id.
-func Update(ctx context.Context, id string, params *UpdateParams) (*clerk.EmailAddress, error) {
-	return getClient().Update(ctx, id, params)
+func Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.EmailAddress, error) {
+	return getClient(ctx).Update(ctx, id, params, opts...)
 }
 
 // Delete deletes an email address.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -32,8 +32,8 @@ type CreateParams struct {
 }
 
 // Create creates a new email address.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.EmailAddress, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.EmailAddress, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	emailAddress := &clerk.EmailAddress{}
 	err := c.Backend.Call(ctx, req, emailAddress)
@@ -41,12 +41,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Email
 }
 
 // Get retrieves an email address.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.EmailAddress, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.EmailAddress, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	emailAddress := &clerk.EmailAddress{}
 	err = c.Backend.Call(ctx, req, emailAddress)
 	return emailAddress, err
@@ -59,12 +59,12 @@ type UpdateParams struct {
 }
 
 // Update updates the email address specified by id.
-func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*clerk.EmailAddress, error) {
+func (c *Client) Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.EmailAddress, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	emailAddress := &clerk.EmailAddress{}
 	err = c.Backend.Call(ctx, req, emailAddress)
@@ -72,12 +72,12 @@ func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*
 }
 
 // Delete deletes an email address.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	emailAddress := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, emailAddress)
 	return emailAddress, err
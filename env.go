@@ -0,0 +1,199 @@
+package clerk
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables that can be used to configure the library.
+const (
+	// EnvSecretKey holds the Clerk secret key.
+	EnvSecretKey = "CLERK_SECRET_KEY"
+	// EnvAPIURL holds the base URL of the Clerk API, without the
+	// version path segment.
+	EnvAPIURL = "CLERK_API_URL"
+	// EnvAPIVersion holds the version path segment for the Clerk
+	// API, like "v1".
+	EnvAPIVersion = "CLERK_API_VERSION"
+	// EnvJWTKey holds the PEM encoded public key that can be used to
+	// verify session tokens without fetching the JSON Web Key Set.
+	EnvJWTKey = "CLERK_JWT_KEY"
+	// EnvPublishableKey holds the Clerk publishable key.
+	EnvPublishableKey = "CLERK_PUBLISHABLE_KEY"
+)
+
+const (
+	defaultAPIBaseURL = "https://api.clerk.com"
+	defaultAPIVersion = "v1"
+)
+
+// KeyMode describes whether a Clerk key belongs to a development
+// or a production instance.
+type KeyMode string
+
+const (
+	// KeyModeTest is the mode for keys of development instances.
+	KeyModeTest KeyMode = "test"
+	// KeyModeLive is the mode for keys of production instances.
+	KeyModeLive KeyMode = "live"
+)
+
+// Parses the mode from a key in the format <prefix>_<mode>_<value>.
+func parseKeyMode(key, prefix string) (KeyMode, string, error) {
+	for _, mode := range []KeyMode{KeyModeTest, KeyModeLive} {
+		modePrefix := prefix + "_" + string(mode) + "_"
+		if value, ok := strings.CutPrefix(key, modePrefix); ok && value != "" {
+			return mode, value, nil
+		}
+	}
+	return "", "", fmt.Errorf("clerk: invalid key format, expected %s_test_ or %s_live_ prefix", prefix, prefix)
+}
+
+// SecretKeyMode validates the format of the Clerk secret key and
+// returns its mode.
+func SecretKeyMode(key string) (KeyMode, error) {
+	mode, _, err := parseKeyMode(key, "sk")
+	return mode, err
+}
+
+// PublishableKey holds the information that is encoded in a Clerk
+// publishable key.
+type PublishableKey struct {
+	// Mode is the mode of the publishable key.
+	Mode KeyMode
+	// FrontendAPI is the host of the instance's Frontend API, e.g.
+	// clerk.example.com.
+	FrontendAPI string
+	// Key is the publishable key itself.
+	Key string
+}
+
+// FrontendAPIURL returns the URL for the instance's Frontend API.
+// The URL is also the issuer of the instance's session tokens.
+func (pk *PublishableKey) FrontendAPIURL() string {
+	return "https://" + pk.FrontendAPI
+}
+
+// ParsePublishableKey decodes a Clerk publishable key in the format
+// pk_<mode>_<base64 encoded Frontend API host>.
+func ParsePublishableKey(key string) (*PublishableKey, error) {
+	mode, encoded, err := parseKeyMode(key, "pk")
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("clerk: invalid publishable key: %w", err)
+	}
+	frontendAPI, ok := strings.CutSuffix(string(decoded), "$")
+	if !ok || frontendAPI == "" {
+		return nil, fmt.Errorf("clerk: invalid publishable key")
+	}
+	return &PublishableKey{
+		Mode:        mode,
+		FrontendAPI: frontendAPI,
+		Key:         key,
+	}, nil
+}
+
+// EnvConfig holds the library configuration that can be read from
+// environment variables.
+type EnvConfig struct {
+	BackendConfig
+	// KeyMode is the mode of the secret key.
+	KeyMode KeyMode
+	// JWTKey is the PEM encoded public key for verifying session
+	// tokens, if one was set.
+	JWTKey string
+	// JWK is the JSON Web Key that was parsed from the JWTKey, if
+	// one was set. Use it to verify session tokens without fetching
+	// the JSON Web Key Set.
+	JWK *JSONWebKey
+	// PublishableKey holds the decoded publishable key, if one was
+	// set.
+	PublishableKey *PublishableKey
+}
+
+// ConfigFromEnv reads the library configuration from the
+// environment.
+// The CLERK_SECRET_KEY variable is required and must be a valid
+// secret key. If a CLERK_PUBLISHABLE_KEY is set, it must have the
+// same mode as the secret key. If a CLERK_JWT_KEY is set, it must be
+// a valid public key. The PEM header and footer can be omitted, as in
+// the key that is shown in the Clerk Dashboard.
+func ConfigFromEnv() (*EnvConfig, error) {
+	key := strings.TrimSpace(os.Getenv(EnvSecretKey))
+	if key == "" {
+		return nil, fmt.Errorf("clerk: missing %s", EnvSecretKey)
+	}
+	mode, err := SecretKeyMode(key)
+	if err != nil {
+		return nil, err
+	}
+	config := &EnvConfig{
+		KeyMode: mode,
+		JWTKey:  os.Getenv(EnvJWTKey),
+	}
+	config.Key = String(key)
+
+	if config.JWTKey != "" {
+		pemKey := config.JWTKey
+		if !strings.HasPrefix(pemKey, "-----BEGIN") {
+			pemKey = "-----BEGIN PUBLIC KEY-----\n" + pemKey + "\n-----END PUBLIC KEY-----"
+		}
+		config.JWK, err = JSONWebKeyFromPEM(pemKey)
+		if err != nil {
+			return nil, fmt.Errorf("clerk: invalid %s: %w", EnvJWTKey, err)
+		}
+	}
+
+	baseURL := os.Getenv(EnvAPIURL)
+	apiVersion := os.Getenv(EnvAPIVersion)
+	if baseURL != "" || apiVersion != "" {
+		if baseURL == "" {
+			baseURL = defaultAPIBaseURL
+		}
+		if apiVersion == "" {
+			apiVersion = defaultAPIVersion
+		}
+		apiURL, err := JoinPath(baseURL, apiVersion)
+		if err != nil {
+			return nil, fmt.Errorf("clerk: invalid %s: %w", EnvAPIURL, err)
+		}
+		config.URL = String(apiURL)
+	}
+
+	if publishableKey := strings.TrimSpace(os.Getenv(EnvPublishableKey)); publishableKey != "" {
+		pk, err := ParsePublishableKey(publishableKey)
+		if err != nil {
+			return nil, err
+		}
+		if pk.Mode != mode {
+			return nil, fmt.Errorf("clerk: %s is a %s key, but %s is a %s key", EnvPublishableKey, pk.Mode, EnvSecretKey, mode)
+		}
+		config.PublishableKey = pk
+	}
+	return config, nil
+}
+
+// BackendConfigFromEnv returns a BackendConfig with values read from
+// the environment. See ConfigFromEnv for details.
+func BackendConfigFromEnv() (*BackendConfig, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &config.BackendConfig, nil
+}
+
+// NewBackendFromEnv returns a Backend configured from the
+// environment. See ConfigFromEnv for details.
+func NewBackendFromEnv() (Backend, error) {
+	config, err := BackendConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewBackend(config), nil
+}
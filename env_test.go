@@ -0,0 +1,134 @@
+package clerk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretKeyMode(t *testing.T) {
+	t.Parallel()
+	mode, err := SecretKeyMode("sk_test_123")
+	require.NoError(t, err)
+	assert.Equal(t, KeyModeTest, mode)
+
+	mode, err = SecretKeyMode("sk_live_123")
+	require.NoError(t, err)
+	assert.Equal(t, KeyModeLive, mode)
+
+	for _, key := range []string{"", "sk_test_", "sk_prod_123", "pk_test_123", "123"} {
+		_, err = SecretKeyMode(key)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestParsePublishableKey(t *testing.T) {
+	t.Parallel()
+	pk, err := ParsePublishableKey("pk_live_Y2xlcmsuZXhhbXBsZS5jb20k")
+	require.NoError(t, err)
+	assert.Equal(t, KeyModeLive, pk.Mode)
+	assert.Equal(t, "clerk.example.com", pk.FrontendAPI)
+	assert.Equal(t, "https://clerk.example.com", pk.FrontendAPIURL())
+
+	pk, err = ParsePublishableKey("pk_test_aGFwcHktaGlwcG8tMS5jbGVyay5hY2NvdW50cy5kZXYk")
+	require.NoError(t, err)
+	assert.Equal(t, KeyModeTest, pk.Mode)
+	assert.Equal(t, "happy-hippo-1.clerk.accounts.dev", pk.FrontendAPI)
+
+	for _, key := range []string{
+		"",
+		"pk_test_",
+		"sk_test_Y2xlcmsuZXhhbXBsZS5jb20k",
+		// Not base64 encoded
+		"pk_test_clerk.example.com",
+		// Missing the $ suffix
+		"pk_test_Y2xlcmsuZXhhbXBsZS5jb20",
+	} {
+		_, err = ParsePublishableKey(key)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	jwtKey := testPEMPublicKey(t)
+	t.Setenv(EnvSecretKey, "sk_live_123")
+	t.Setenv(EnvPublishableKey, "pk_live_Y2xlcmsuZXhhbXBsZS5jb20k")
+	t.Setenv(EnvJWTKey, jwtKey)
+	t.Setenv(EnvAPIURL, "")
+	t.Setenv(EnvAPIVersion, "")
+
+	config, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, KeyModeLive, config.KeyMode)
+	assert.Equal(t, "sk_live_123", *config.Key)
+	assert.Equal(t, jwtKey, config.JWTKey)
+	require.NotNil(t, config.JWK)
+	assert.Equal(t, "ES256", config.JWK.Algorithm)
+	require.NotNil(t, config.PublishableKey)
+	assert.Equal(t, "clerk.example.com", config.PublishableKey.FrontendAPI)
+	assert.Equal(t, "pk_live_Y2xlcmsuZXhhbXBsZS5jb20k", config.PublishableKey.Key)
+
+	// The JWT key can omit the PEM header and footer.
+	lines := strings.Split(strings.TrimSpace(jwtKey), "\n")
+	t.Setenv(EnvJWTKey, strings.Join(lines[1:len(lines)-1], ""))
+	config, err = ConfigFromEnv()
+	require.NoError(t, err)
+	require.NotNil(t, config.JWK)
+
+	// Invalid JWT key
+	t.Setenv(EnvJWTKey, "the-jwt-key")
+	_, err = ConfigFromEnv()
+	require.Error(t, err)
+	t.Setenv(EnvJWTKey, "")
+	// The default URL will be used.
+	assert.Nil(t, config.URL)
+
+	// Custom API URL and version.
+	t.Setenv(EnvAPIURL, "https://api.example.com/")
+	t.Setenv(EnvAPIVersion, "v2")
+	backendConfig, err := BackendConfigFromEnv()
+	require.NoError(t, err)
+	require.NotNil(t, backendConfig.URL)
+	assert.Equal(t, "https://api.example.com/v2", *backendConfig.URL)
+
+	t.Setenv(EnvAPIVersion, "")
+	b, err := NewBackendFromEnv()
+	require.NoError(t, err)
+	defaultBackend, ok := b.(*defaultBackend)
+	require.True(t, ok)
+	assert.Equal(t, "https://api.example.com/v1", defaultBackend.URL)
+	assert.Equal(t, "sk_live_123", defaultBackend.Key)
+
+	// Mismatched key modes
+	t.Setenv(EnvPublishableKey, "pk_test_Y2xlcmsuZXhhbXBsZS5jb20k")
+	_, err = ConfigFromEnv()
+	require.Error(t, err)
+
+	// Invalid secret key
+	t.Setenv(EnvPublishableKey, "")
+	t.Setenv(EnvSecretKey, "invalid")
+	_, err = ConfigFromEnv()
+	require.Error(t, err)
+
+	// Missing secret key
+	t.Setenv(EnvSecretKey, "")
+	_, err = NewBackendFromEnv()
+	require.Error(t, err)
+}
+
+// Returns a PEM encoded ECDSA public key.
+func testPEMPublicKey(t *testing.T) string {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
@@ -0,0 +1,190 @@
+package clerk
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors that API errors can be matched against with
+// errors.Is.
+//
+//	_, err := user.Get(ctx, "user_123")
+//	if errors.Is(err, clerk.ErrNotFound) {
+//		// The user does not exist.
+//	}
+var (
+	// ErrNotFound matches 404 Not Found API responses.
+	ErrNotFound = errors.New("clerk: resource not found")
+	// ErrUnauthorized matches 401 Unauthorized API responses.
+	ErrUnauthorized = errors.New("clerk: unauthorized")
+	// ErrForbidden matches 403 Forbidden API responses.
+	ErrForbidden = errors.New("clerk: forbidden")
+	// ErrRateLimited matches 429 Too Many Requests API responses.
+	// Use RetryAfter to find out when the request can be retried.
+	ErrRateLimited = errors.New("clerk: rate limited")
+	// ErrConflict matches 409 Conflict API responses, as well as
+	// errors for identifiers that already exist.
+	ErrConflict = errors.New("clerk: conflict")
+	// ErrValidation matches 400 Bad Request and 422 Unprocessable
+	// Entity API responses.
+	ErrValidation = errors.New("clerk: validation failed")
+	// ErrServer matches 5xx API responses.
+	ErrServer = errors.New("clerk: server error")
+)
+
+// Error codes that signify a conflict with an existing resource.
+var conflictErrorCodes = []string{
+	"form_identifier_exists",
+}
+
+// Reports whether the HTTP status code corresponds to the target
+// sentinel error.
+func statusMatches(statusCode int, target error) bool {
+	switch target {
+	case ErrNotFound:
+		return statusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return statusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return statusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return statusCode == http.StatusTooManyRequests
+	case ErrConflict:
+		return statusCode == http.StatusConflict
+	case ErrValidation:
+		return statusCode == http.StatusBadRequest ||
+			statusCode == http.StatusUnprocessableEntity
+	case ErrServer:
+		return statusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// Is reports whether the APIErrorResponse matches the target error.
+// Use it through errors.Is with one of the sentinel errors, like
+// ErrNotFound.
+func (resp *APIErrorResponse) Is(target error) bool {
+	if statusMatches(resp.HTTPStatusCode, target) {
+		return true
+	}
+	if target == ErrConflict {
+		for _, code := range conflictErrorCodes {
+			if resp.HasCode(code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasCode returns true if any of the errors in the response has
+// the provided error code.
+func (resp *APIErrorResponse) HasCode(code string) bool {
+	for _, err := range resp.Errors {
+		if err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryAfter returns the duration to wait before retrying the
+// request, as instructed by the Retry-After response header.
+// Returns false if the header is missing.
+func (resp *APIErrorResponse) RetryAfter() (time.Duration, bool) {
+	if resp.Response == nil {
+		return 0, false
+	}
+	return retryAfter(resp.Response)
+}
+
+// UnexpectedResponseError is returned for non-successful API
+// responses that don't contain a familiar error body. This might
+// happen when the Clerk API encounters an unexpected server error.
+type UnexpectedResponseError struct {
+	APIResource
+	// HTTPStatusCode is the response status code.
+	HTTPStatusCode int
+	// Body is the raw response body.
+	Body []byte
+}
+
+// Error returns the raw response body, or the response status if
+// the body is empty.
+func (e *UnexpectedResponseError) Error() string {
+	if len(e.Body) == 0 {
+		return "clerk: unexpected response status " + http.StatusText(e.HTTPStatusCode)
+	}
+	return string(e.Body)
+}
+
+// Is reports whether the error matches the target error. Use it
+// through errors.Is with one of the sentinel errors, like ErrServer.
+func (e *UnexpectedResponseError) Is(target error) bool {
+	return statusMatches(e.HTTPStatusCode, target)
+}
+
+// RetryAfter returns the duration to wait before retrying the
+// request, as instructed by the Retry-After response header.
+// Returns false if the header is missing.
+func (e *UnexpectedResponseError) RetryAfter() (time.Duration, bool) {
+	if e.Response == nil {
+		return 0, false
+	}
+	return retryAfter(e.Response)
+}
+
+// IsNotFound returns true if the error is a 404 Not Found API error.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized returns true if the error is a 401 Unauthorized
+// API error.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden returns true if the error is a 403 Forbidden API error.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsRateLimited returns true if the error is a 429 Too Many Requests
+// API error.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsConflict returns true if the error signifies a conflict with an
+// existing resource, like a duplicate identifier.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsValidation returns true if the error is caused by invalid
+// request parameters.
+func IsValidation(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// IsServerError returns true if the error is a 5xx API error.
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServer)
+}
+
+// RetryAfter returns the duration to wait before retrying a
+// request that failed with the provided error, as instructed by
+// the Retry-After response header.
+// Returns false if the error is not an API error or the header is
+// missing.
+func RetryAfter(err error) (time.Duration, bool) {
+	var retryable interface {
+		RetryAfter() (time.Duration, bool)
+	}
+	if !errors.As(err, &retryable) {
+		return 0, false
+	}
+	return retryable.RetryAfter()
+}
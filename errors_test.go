@@ -0,0 +1,124 @@
+package clerk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorResponse_Is(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		status int
+		codes  []string
+		want   error
+	}{
+		{status: http.StatusNotFound, want: ErrNotFound},
+		{status: http.StatusUnauthorized, want: ErrUnauthorized},
+		{status: http.StatusForbidden, want: ErrForbidden},
+		{status: http.StatusTooManyRequests, want: ErrRateLimited},
+		{status: http.StatusConflict, want: ErrConflict},
+		{status: http.StatusBadRequest, want: ErrValidation},
+		{status: http.StatusUnprocessableEntity, want: ErrValidation},
+		{status: http.StatusInternalServerError, want: ErrServer},
+		{status: http.StatusBadGateway, want: ErrServer},
+		{status: http.StatusUnprocessableEntity, codes: []string{"form_identifier_exists"}, want: ErrConflict},
+	} {
+		apiErr := &APIErrorResponse{HTTPStatusCode: tc.status}
+		for _, code := range tc.codes {
+			apiErr.Errors = append(apiErr.Errors, Error{Code: code})
+		}
+		// Wrap the error, to make sure that the errors package
+		// inspects the whole chain.
+		err := fmt.Errorf("wrapped: %w", apiErr)
+		assert.ErrorIs(t, err, tc.want, "status %d", tc.status)
+		for _, other := range []error{ErrNotFound, ErrForbidden, ErrRateLimited} {
+			if other == tc.want {
+				continue
+			}
+			assert.NotErrorIs(t, err, other, "status %d", tc.status)
+		}
+	}
+}
+
+func TestAPIErrorResponse_HasCode(t *testing.T) {
+	t.Parallel()
+	apiErr := &APIErrorResponse{
+		Errors: []Error{{Code: "form_param_missing"}, {Code: "form_identifier_exists"}},
+	}
+	assert.True(t, apiErr.HasCode("form_identifier_exists"))
+	assert.True(t, apiErr.HasCode("form_param_missing"))
+	assert.False(t, apiErr.HasCode("resource_not_found"))
+}
+
+func TestErrorHelpers(t *testing.T) {
+	t.Parallel()
+	assert.True(t, IsNotFound(&APIErrorResponse{HTTPStatusCode: http.StatusNotFound}))
+	assert.True(t, IsUnauthorized(&APIErrorResponse{HTTPStatusCode: http.StatusUnauthorized}))
+	assert.True(t, IsForbidden(&APIErrorResponse{HTTPStatusCode: http.StatusForbidden}))
+	assert.True(t, IsRateLimited(&UnexpectedResponseError{HTTPStatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsConflict(&APIErrorResponse{HTTPStatusCode: http.StatusConflict}))
+	assert.True(t, IsValidation(&APIErrorResponse{HTTPStatusCode: http.StatusUnprocessableEntity}))
+	assert.True(t, IsServerError(&UnexpectedResponseError{HTTPStatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, IsNotFound(errors.New("not found")))
+	assert.False(t, IsNotFound(nil))
+}
+
+func TestBackendCall_TypedErrors(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/not-found":
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errors":[{"code":"resource_not_found"}]}`))
+			require.NoError(t, err)
+		case "/rate-limited":
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`Too many requests`))
+			require.NoError(t, err)
+		case "/unexpected":
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+	})
+	ctx := context.Background()
+
+	err := backend.Call(ctx, NewAPIRequest(http.MethodGet, "/not-found"), &testResource{})
+	require.True(t, IsNotFound(err))
+	var apiErr *APIErrorResponse
+	require.ErrorAs(t, err, &apiErr)
+	assert.True(t, apiErr.HasCode("resource_not_found"))
+
+	// Non-JSON error bodies result in an UnexpectedResponseError.
+	err = backend.Call(ctx, NewAPIRequest(http.MethodGet, "/rate-limited"), &testResource{})
+	require.True(t, IsRateLimited(err))
+	var unexpectedErr *UnexpectedResponseError
+	require.ErrorAs(t, err, &unexpectedErr)
+	assert.Equal(t, http.StatusTooManyRequests, unexpectedErr.HTTPStatusCode)
+	assert.Equal(t, "Too many requests", string(unexpectedErr.Body))
+	assert.Equal(t, "Too many requests", err.Error())
+	retryAfter, ok := RetryAfter(err)
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	err = backend.Call(ctx, NewAPIRequest(http.MethodGet, "/unexpected"), &testResource{})
+	require.True(t, IsServerError(err))
+	require.ErrorAs(t, err, &unexpectedErr)
+	assert.Empty(t, unexpectedErr.Body)
+	assert.Contains(t, err.Error(), "Bad Gateway")
+	_, ok = RetryAfter(err)
+	assert.False(t, ok)
+}
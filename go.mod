@@ -1,6 +1,6 @@
 module github.com/clerk/clerk-sdk-go/v2
 
-go 1.19
+go 1.21
 
 require (
 	github.com/go-jose/go-jose/v3 v3.0.1
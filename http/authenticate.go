@@ -0,0 +1,437 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
+)
+
+// Cookies and query parameters that are used for authenticating
+// requests.
+const (
+	sessionCookie       = "__session"
+	clientUATCookie     = "__client_uat"
+	handshakeParam      = "__clerk_handshake"
+	handshakeReason     = "__clerk_hs_reason"
+	devBrowserParam     = "__clerk_db_jwt"
+	redirectCountCookie = "__clerk_redirect_count"
+)
+
+// The number of consecutive handshake redirects after which the
+// request is considered signed out.
+const maxHandshakeRedirects = 3
+
+// AuthStatus is the outcome of authenticating a request.
+type AuthStatus string
+
+const (
+	// AuthStatusSignedIn means that the request has a valid session
+	// token.
+	AuthStatusSignedIn AuthStatus = "signed-in"
+	// AuthStatusSignedOut means that the request doesn't belong to
+	// an active session.
+	AuthStatusSignedOut AuthStatus = "signed-out"
+	// AuthStatusHandshake means that the session token can't be
+	// trusted and must be refreshed with a redirect to the Clerk
+	// Frontend API.
+	AuthStatusHandshake AuthStatus = "handshake"
+)
+
+// AuthReason explains why a request is signed out or needs a
+// handshake.
+type AuthReason string
+
+const (
+	AuthReasonSessionTokenAndUATMissing      AuthReason = "session-token-and-uat-missing"
+	AuthReasonSessionTokenMissing            AuthReason = "session-token-missing"
+	AuthReasonClientUATMissing               AuthReason = "client-uat-missing"
+	AuthReasonSessionTokenIATBeforeClientUAT AuthReason = "session-token-iat-before-client-uat"
+	AuthReasonSessionTokenExpired            AuthReason = "session-token-expired"
+	AuthReasonSessionTokenInvalid            AuthReason = "session-token-invalid"
+	AuthReasonHandshakeTokenInvalid          AuthReason = "handshake-token-invalid"
+	AuthReasonRedirectLoop                   AuthReason = "redirect-loop"
+)
+
+// RequestState holds the result of authenticating a request.
+type RequestState struct {
+	// Status is the outcome of the authentication.
+	Status AuthStatus
+	// Reason explains why the request is signed out or needs a
+	// handshake.
+	Reason AuthReason
+	// Token is the verified session token of signed in requests.
+	Token string
+	// Claims are the session claims of signed in requests.
+	Claims *clerk.SessionClaims
+	// Err is the error of a session token that failed verification.
+	Err error
+	// Headers must be added to the response. They can contain
+	// Set-Cookie headers with refreshed session cookies and, for the
+	// handshake status, the Location of the redirect.
+	Headers http.Header
+}
+
+// Session token claims for the handshake token.
+type handshakeClaims struct {
+	Handshake []string `json:"handshake"`
+}
+
+// AuthenticateRequest authenticates the request with the session
+// token in the Authorization header or the Clerk session cookies.
+// Requests with session cookies that can't be trusted, because the
+// session token is expired or older than the client, get the
+// handshake status. The handshake is a redirect to the Clerk Frontend
+// API, which responds with a new session token. Only requests for
+// HTML documents can be redirected; the rest are signed out.
+// The publishable key is needed for the handshake redirect. It's read
+// from the CLERK_PUBLISHABLE_KEY environment variable, unless the
+// PublishableKey or ProxyURL options are provided.
+// Unless the JSONWebKey or JWKSCache options are provided, calls
+// with the same JWKSClient share a cache for the JSON Web Key Set,
+// so the client should be created once and reused.
+// The returned error is set only for invalid configuration.
+func AuthenticateRequest(r *http.Request, opts ...AuthorizationOption) (*RequestState, error) {
+	params, err := newAuthorizationParams(opts)
+	if err != nil {
+		return nil, err
+	}
+	if params.JWK == nil && params.JWKSCache == nil {
+		params.JWKSCache = sharedJWKSCache(params.JWKSClient)
+	}
+	return authenticateRequest(r, params)
+}
+
+// The caches that AuthenticateRequest uses, one for each JWKSClient.
+// Unlike the middleware caches, they're shared by all calls, so they
+// use the system clock even if the Clock option is provided. Without
+// a JWKSClient, the key sets are fetched with the Backend in the
+// request context, or the default Backend.
+var sharedJWKSCaches sync.Map
+
+func sharedJWKSCache(client *jwks.Client) *jwks.Cache {
+	if cache, ok := sharedJWKSCaches.Load(client); ok {
+		return cache.(*jwks.Cache)
+	}
+	cache, _ := sharedJWKSCaches.LoadOrStore(client, jwks.NewCache(&jwks.CacheConfig{
+		Client: client,
+	}))
+	return cache.(*jwks.Cache)
+}
+
+// WithCookieAuthorization authenticates the request with
+// AuthenticateRequest. For signed in requests, the session claims are
+// written to the http.Request context. Signed out requests are passed
+// to the next handler without session claims, and any verification
+// error is available with AuthorizationErrorFromContext.
+// Requests that need a handshake are redirected with HTTP 307
+// Temporary Redirect.
+func WithCookieAuthorization(opts ...AuthorizationOption) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		cache := &middlewareCache{}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			params, err := newAuthorizationParams(opts)
+			if err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			cache.apply(params)
+			state, err := authenticateRequest(r, params)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			for name, values := range state.Headers {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+
+			switch state.Status {
+			case AuthStatusHandshake:
+				w.WriteHeader(http.StatusTemporaryRedirect)
+			case AuthStatusSignedIn:
+				next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), state.Claims)))
+			default:
+				ctx := r.Context()
+				if state.Err != nil {
+					ctx = context.WithValue(ctx, authorizationErrorKey, state.Err)
+				}
+				next.ServeHTTP(w, r.WithContext(ctx))
+			}
+		})
+	}
+}
+
+func authenticateRequest(r *http.Request, params *AuthorizationParams) (*RequestState, error) {
+	// Requests with a session token in the header are authenticated
+	// with it, without checking cookies.
+	if token := params.AuthorizationJWTExtractor(r); token != "" {
+		return verifySessionToken(r.Context(), params, token, nil), nil
+	}
+
+	if token := handshakeToken(r); token != "" {
+		return resolveHandshake(r, params, token)
+	}
+
+	token := cookieValue(r, sessionCookie)
+	clientUAT, _ := strconv.ParseInt(cookieValue(r, clientUATCookie), 10, 64)
+	hasActiveClient := clientUAT > 0
+	switch {
+	case !hasActiveClient && token == "":
+		return signedOut(AuthReasonSessionTokenAndUATMissing, nil, nil), nil
+	case token == "":
+		return handshake(r, params, AuthReasonSessionTokenMissing)
+	case !hasActiveClient:
+		return handshake(r, params, AuthReasonClientUATMissing)
+	}
+
+	decoded, err := jwt.Decode(r.Context(), &jwt.DecodeParams{Token: token})
+	if err != nil {
+		return signedOut(AuthReasonSessionTokenInvalid, err, nil), nil
+	}
+	if decoded.IssuedAt == nil || *decoded.IssuedAt < clientUAT {
+		return handshake(r, params, AuthReasonSessionTokenIATBeforeClientUAT)
+	}
+	state := verifySessionToken(r.Context(), params, token, nil)
+	if errors.Is(state.Err, jwt.ErrTokenExpired) {
+		return handshake(r, params, AuthReasonSessionTokenExpired)
+	}
+	return state, nil
+}
+
+// Verifies the session token and returns a signed in or signed out
+// state with the headers.
+func verifySessionToken(ctx context.Context, params *AuthorizationParams, token string, headers http.Header) *RequestState {
+	verifyParams := params.VerifyParams
+	verifyParams.Token = token
+	claims, err := jwt.Verify(ctx, &verifyParams)
+	if err != nil {
+		return signedOut(AuthReasonSessionTokenInvalid, err, headers)
+	}
+	return &RequestState{
+		Status:  AuthStatusSignedIn,
+		Token:   token,
+		Claims:  claims,
+		Headers: headers,
+	}
+}
+
+func signedOut(reason AuthReason, err error, headers http.Header) *RequestState {
+	return &RequestState{
+		Status:  AuthStatusSignedOut,
+		Reason:  reason,
+		Err:     err,
+		Headers: headers,
+	}
+}
+
+// Returns the handshake state with the redirect to the Frontend API,
+// or a signed out state if the request can't be redirected.
+func handshake(r *http.Request, params *AuthorizationParams, reason AuthReason) (*RequestState, error) {
+	if !isHandshakeEligible(r) {
+		return signedOut(reason, nil, nil), nil
+	}
+	redirects, _ := strconv.Atoi(cookieValue(r, redirectCountCookie))
+	if redirects >= maxHandshakeRedirects {
+		return signedOut(AuthReasonRedirectLoop, nil, nil), nil
+	}
+
+	frontendAPIURL, isDevelopment, err := frontendAPI(params)
+	if err != nil {
+		return nil, err
+	}
+	location, err := url.Parse(strings.TrimSuffix(frontendAPIURL, "/") + "/v1/client/handshake")
+	if err != nil {
+		return nil, fmt.Errorf("clerk: invalid frontend API URL %s: %w", frontendAPIURL, err)
+	}
+	query := location.Query()
+	query.Set("redirect_url", requestURL(r, params).String())
+	query.Set("suffixed_cookies", "false")
+	query.Set(handshakeReason, string(reason))
+	if devBrowser := r.URL.Query().Get(devBrowserParam); isDevelopment && devBrowser != "" {
+		query.Set(devBrowserParam, devBrowser)
+	} else if devBrowser := cookieValue(r, devBrowserParam); isDevelopment && devBrowser != "" {
+		query.Set(devBrowserParam, devBrowser)
+	}
+	location.RawQuery = query.Encode()
+
+	headers := http.Header{}
+	headers.Set("Location", location.String())
+	headers.Set("Cache-Control", "no-store")
+	headers.Add("Set-Cookie", (&http.Cookie{
+		Name:     redirectCountCookie,
+		Value:    strconv.Itoa(redirects + 1),
+		Path:     "/",
+		MaxAge:   3,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}).String())
+	return &RequestState{
+		Status:  AuthStatusHandshake,
+		Reason:  reason,
+		Headers: headers,
+	}, nil
+}
+
+// Verifies the handshake token from the Frontend API and sets the
+// cookies it contains.
+// If the handshake token is in the query, the request is redirected
+// to the same URL without it.
+func resolveHandshake(r *http.Request, params *AuthorizationParams, token string) (*RequestState, error) {
+	// The handshake token is signed by the instance, but it's not a
+	// session token.
+	verifyParams := params.VerifyParams
+	verifyParams.Token = token
+	verifyParams.IssuerValidator = func(_ string) bool { return true }
+	verifyParams.Audience = nil
+	verifyParams.AuthorizedPartyHandler = nil
+	verifyParams.ActorValidator = nil
+	_, claims, err := jwt.VerifyWithClaims[handshakeClaims](r.Context(), &verifyParams)
+	if err != nil {
+		return signedOut(AuthReasonHandshakeTokenInvalid, err, nil), nil
+	}
+
+	headers := http.Header{}
+	for _, cookie := range claims.Handshake {
+		headers.Add("Set-Cookie", cookie)
+	}
+
+	if r.URL.Query().Has(handshakeParam) {
+		location := requestURL(r, params)
+		query := location.Query()
+		query.Del(handshakeParam)
+		query.Del(handshakeReason)
+		query.Del(devBrowserParam)
+		location.RawQuery = query.Encode()
+		headers.Set("Location", location.String())
+		headers.Set("Cache-Control", "no-store")
+		return &RequestState{
+			Status:  AuthStatusHandshake,
+			Headers: headers,
+		}, nil
+	}
+
+	// The handshake token is not needed after it's resolved.
+	headers.Add("Set-Cookie", (&http.Cookie{
+		Name:   handshakeParam,
+		Path:   "/",
+		MaxAge: -1,
+	}).String())
+	var sessionToken string
+	for _, cookie := range (&http.Response{Header: http.Header{"Set-Cookie": claims.Handshake}}).Cookies() {
+		if cookie.Name == sessionCookie {
+			sessionToken = cookie.Value
+		}
+	}
+	if sessionToken == "" {
+		return signedOut(AuthReasonSessionTokenMissing, nil, headers), nil
+	}
+	return verifySessionToken(r.Context(), params, sessionToken, headers), nil
+}
+
+// Returns the handshake token from the query or the cookies.
+func handshakeToken(r *http.Request) string {
+	if token := r.URL.Query().Get(handshakeParam); token != "" {
+		return token
+	}
+	return cookieValue(r, handshakeParam)
+}
+
+// Only requests for documents can be redirected for a handshake.
+func isHandshakeEligible(r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	switch r.Header.Get("Sec-Fetch-Dest") {
+	case "document", "iframe":
+		return true
+	case "":
+		return strings.HasPrefix(r.Header.Get("Accept"), "text/html")
+	}
+	return false
+}
+
+// Returns the URL of the Frontend API for the handshake, and whether
+// it belongs to a development instance.
+func frontendAPI(params *AuthorizationParams) (string, bool, error) {
+	publishableKey := os.Getenv(clerk.EnvPublishableKey)
+	if params.PublishableKey != nil {
+		publishableKey = *params.PublishableKey
+	}
+	var pk *clerk.PublishableKey
+	if publishableKey != "" {
+		var err error
+		pk, err = clerk.ParsePublishableKey(publishableKey)
+		if err != nil {
+			return "", false, err
+		}
+	}
+	isDevelopment := pk != nil && pk.Mode == clerk.KeyModeTest
+	if params.ProxyURL != nil {
+		return *params.ProxyURL, isDevelopment, nil
+	}
+	if pk == nil {
+		return "", false, fmt.Errorf("clerk: missing publishable key for the handshake")
+	}
+	return pk.FrontendAPIURL(), isDevelopment, nil
+}
+
+// Returns the absolute URL of the request, as seen by the client.
+// The X-Forwarded-Proto and X-Forwarded-Host headers are used only
+// for requests from trusted proxies.
+func requestURL(r *http.Request, params *AuthorizationParams) *url.URL {
+	u := *r.URL
+	u.Scheme = "http"
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	u.Host = r.Host
+	if !isTrustedProxy(r.RemoteAddr, params.TrustedProxies) {
+		return &u
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		u.Scheme, _, _ = strings.Cut(proto, ",")
+	}
+	if host := r.Header.Get("X-Forwarded-Host"); host != "" {
+		u.Host, _, _ = strings.Cut(host, ",")
+	}
+	return &u
+}
+
+// Checks if the remote address of the request belongs to one of the
+// trusted proxies.
+func isTrustedProxy(remoteAddr string, proxies []netip.Prefix) bool {
+	if len(proxies) == 0 {
+		return false
+	}
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, proxy := range proxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func cookieValue(r *http.Request, name string) string {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
@@ -0,0 +1,434 @@
+package http
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+const testPublishableKey = "pk_live_Y2xlcmsuZXhhbXBsZS5jb20k"
+
+// Returns a function that signs tokens, and the options to verify
+// them with.
+func newTestSigner(t *testing.T) (func(claims map[string]any) string, []AuthorizationOption) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	sign := func(claims map[string]any) string {
+		return clerktest.GenerateJWTWithKey(t, claims, "kid", jose.RS256, privateKey)
+	}
+	return sign, []AuthorizationOption{JSONWebKey(publicKey), PublishableKey(testPublishableKey)}
+}
+
+func newDocumentRequest(t *testing.T, target string, cookies ...*http.Cookie) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	return req
+}
+
+func TestAuthenticateRequest(t *testing.T) {
+	t.Parallel()
+	sign, opts := newTestSigner(t)
+	now := time.Now()
+	clientUAT := now.Add(-time.Minute).Unix()
+	validToken := sign(map[string]any{
+		"iss": "https://clerk.example.com",
+		"sub": "user_123",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Minute).Unix(),
+	})
+	outdatedToken := sign(map[string]any{
+		"iss": "https://clerk.example.com",
+		"sub": "user_123",
+		"iat": clientUAT - 60,
+		"exp": now.Add(time.Minute).Unix(),
+	})
+	expiredToken := sign(map[string]any{
+		"iss": "https://clerk.example.com",
+		"sub": "user_123",
+		"iat": now.Add(-time.Hour).Unix(),
+		"exp": now.Add(-50 * time.Minute).Unix(),
+	})
+
+	for _, tc := range []struct {
+		name    string
+		req     *http.Request
+		status  AuthStatus
+		reason  AuthReason
+		subject string
+	}{
+		{
+			name:   "no cookies",
+			req:    newDocumentRequest(t, "https://example.com/"),
+			status: AuthStatusSignedOut,
+			reason: AuthReasonSessionTokenAndUATMissing,
+		},
+		{
+			name:   "signed out client",
+			req:    newDocumentRequest(t, "https://example.com/", &http.Cookie{Name: "__client_uat", Value: "0"}),
+			status: AuthStatusSignedOut,
+			reason: AuthReasonSessionTokenAndUATMissing,
+		},
+		{
+			name: "valid session token",
+			req: newDocumentRequest(t, "https://example.com/",
+				&http.Cookie{Name: "__session", Value: validToken},
+				&http.Cookie{Name: "__client_uat", Value: strconv.FormatInt(clientUAT, 10)},
+			),
+			status:  AuthStatusSignedIn,
+			subject: "user_123",
+		},
+		{
+			name:   "missing session token",
+			req:    newDocumentRequest(t, "https://example.com/", &http.Cookie{Name: "__client_uat", Value: strconv.FormatInt(clientUAT, 10)}),
+			status: AuthStatusHandshake,
+			reason: AuthReasonSessionTokenMissing,
+		},
+		{
+			name:   "missing client uat",
+			req:    newDocumentRequest(t, "https://example.com/", &http.Cookie{Name: "__session", Value: validToken}),
+			status: AuthStatusHandshake,
+			reason: AuthReasonClientUATMissing,
+		},
+		{
+			name: "session token older than the client",
+			req: newDocumentRequest(t, "https://example.com/",
+				&http.Cookie{Name: "__session", Value: outdatedToken},
+				&http.Cookie{Name: "__client_uat", Value: strconv.FormatInt(clientUAT, 10)},
+			),
+			status: AuthStatusHandshake,
+			reason: AuthReasonSessionTokenIATBeforeClientUAT,
+		},
+		{
+			name: "expired session token",
+			req: newDocumentRequest(t, "https://example.com/",
+				&http.Cookie{Name: "__session", Value: expiredToken},
+				&http.Cookie{Name: "__client_uat", Value: strconv.FormatInt(now.Add(-2*time.Hour).Unix(), 10)},
+			),
+			status: AuthStatusHandshake,
+			reason: AuthReasonSessionTokenExpired,
+		},
+		{
+			name: "too many redirects",
+			req: newDocumentRequest(t, "https://example.com/",
+				&http.Cookie{Name: "__client_uat", Value: strconv.FormatInt(clientUAT, 10)},
+				&http.Cookie{Name: "__clerk_redirect_count", Value: "3"},
+			),
+			status: AuthStatusSignedOut,
+			reason: AuthReasonRedirectLoop,
+		},
+		{
+			name: "invalid session token",
+			req: newDocumentRequest(t, "https://example.com/",
+				&http.Cookie{Name: "__session", Value: "invalid"},
+				&http.Cookie{Name: "__client_uat", Value: strconv.FormatInt(clientUAT, 10)},
+			),
+			status: AuthStatusSignedOut,
+			reason: AuthReasonSessionTokenInvalid,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			state, err := AuthenticateRequest(tc.req, opts...)
+			require.NoError(t, err)
+			require.Equal(t, tc.status, state.Status)
+			require.Equal(t, tc.reason, state.Reason)
+			if tc.subject != "" {
+				require.Equal(t, tc.subject, state.Claims.Subject)
+			}
+		})
+	}
+}
+
+func TestAuthenticateRequest_Header(t *testing.T) {
+	t.Parallel()
+	sign, opts := newTestSigner(t)
+	token := sign(map[string]any{"iss": "https://clerk.example.com", "sub": "user_123"})
+
+	// The Authorization header takes precedence over cookies.
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.AddCookie(&http.Cookie{Name: "__client_uat", Value: "1"})
+	state, err := AuthenticateRequest(req, opts...)
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusSignedIn, state.Status)
+	require.Equal(t, token, state.Token)
+
+	token = sign(map[string]any{"iss": "https://example.com", "sub": "user_123"})
+	req.Header.Set("Authorization", "Bearer "+token)
+	state, err = AuthenticateRequest(req, opts...)
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusSignedOut, state.Status)
+	require.True(t, errors.Is(state.Err, jwt.ErrInvalidIssuer))
+}
+
+func TestAuthenticateRequest_EnvConfig(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	t.Setenv(clerk.EnvSecretKey, "sk_live_123")
+	t.Setenv(clerk.EnvPublishableKey, testPublishableKey)
+	t.Setenv(clerk.EnvJWTKey, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	t.Setenv(clerk.EnvAPIURL, "")
+	t.Setenv(clerk.EnvAPIVersion, "")
+	config, err := clerk.ConfigFromEnv()
+	require.NoError(t, err)
+
+	// The token is verified with the CLERK_JWT_KEY, without fetching
+	// the JSON Web Key Set.
+	token := clerktest.GenerateJWTWithKey(t, map[string]any{"iss": "https://clerk.example.com", "sub": "user_123"}, "kid", jose.RS256, privateKey)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	state, err := AuthenticateRequest(req, EnvConfig(config))
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusSignedIn, state.Status)
+
+	// The issuer is validated against the CLERK_PUBLISHABLE_KEY.
+	token = clerktest.GenerateJWTWithKey(t, map[string]any{"iss": "https://example.com", "sub": "user_123"}, "kid", jose.RS256, privateKey)
+	req.Header.Set("Authorization", "Bearer "+token)
+	state, err = AuthenticateRequest(req, EnvConfig(config))
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusSignedOut, state.Status)
+	require.True(t, errors.Is(state.Err, jwt.ErrInvalidIssuer))
+}
+
+func TestAuthenticateRequest_JWKSCache(t *testing.T) {
+	t.Parallel()
+	kid := "kid-" + t.Name()
+	token, publicKey := clerktest.GenerateJWT(t, map[string]any{"sub": "user_123", "iss": "https://clerk.com"}, kid)
+	jwk, err := json.Marshal(jose.JSONWebKey{Key: publicKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"})
+	require.NoError(t, err)
+	var jwksFetches atomic.Int32
+	clerkAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwksFetches.Add(1)
+		_, err := w.Write([]byte(`{"keys":[` + string(jwk) + `]}`))
+		require.NoError(t, err)
+	}))
+	defer clerkAPI.Close()
+	backend := clerk.NewBackend(&clerk.BackendConfig{
+		HTTPClient: clerkAPI.Client(),
+		URL:        &clerkAPI.URL,
+	})
+
+	// Calls without a JWKSCache share the default cache.
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(clerk.ContextWithBackend(req.Context(), backend))
+		req.Header.Set("Authorization", "Bearer "+token)
+		state, err := AuthenticateRequest(req)
+		require.NoError(t, err)
+		require.NoError(t, state.Err)
+		require.Equal(t, AuthStatusSignedIn, state.Status)
+	}
+	require.Equal(t, int32(1), jwksFetches.Load())
+
+	// Calls with the same JWKSClient share its cache.
+	jwksClient := jwks.NewClient(&clerk.ClientConfig{BackendConfig: clerk.BackendConfig{
+		HTTPClient: clerkAPI.Client(),
+		URL:        &clerkAPI.URL,
+	}})
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		state, err := AuthenticateRequest(req, JWKSClient(jwksClient))
+		require.NoError(t, err)
+		require.NoError(t, state.Err)
+		require.Equal(t, AuthStatusSignedIn, state.Status)
+	}
+	require.Equal(t, int32(2), jwksFetches.Load())
+}
+
+func TestAuthenticateRequest_HandshakeRedirect(t *testing.T) {
+	t.Parallel()
+	_, opts := newTestSigner(t)
+	req := newDocumentRequest(t, "http://internal/dashboard?tab=1", &http.Cookie{Name: "__client_uat", Value: "1"})
+	req.RemoteAddr = "10.0.0.2:4321"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "example.com")
+	state, err := AuthenticateRequest(req, append(opts, TrustedProxies("10.0.0.0/8"))...)
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusHandshake, state.Status)
+
+	location, err := url.Parse(state.Headers.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "clerk.example.com", location.Host)
+	require.Equal(t, "/v1/client/handshake", location.Path)
+	require.Equal(t, "https://example.com/dashboard?tab=1", location.Query().Get("redirect_url"))
+	require.Equal(t, string(AuthReasonSessionTokenMissing), location.Query().Get("__clerk_hs_reason"))
+	require.Contains(t, state.Headers.Get("Set-Cookie"), "__clerk_redirect_count=1")
+
+	// The forwarded headers are ignored unless the request comes from
+	// a trusted proxy.
+	for _, proxies := range [][]string{nil, {"10.0.0.1"}, {"192.168.0.0/16"}} {
+		state, err = AuthenticateRequest(req, append(opts, TrustedProxies(proxies...))...)
+		require.NoError(t, err)
+		location, err = url.Parse(state.Headers.Get("Location"))
+		require.NoError(t, err)
+		require.Equal(t, "http://internal/dashboard?tab=1", location.Query().Get("redirect_url"))
+	}
+	_, err = AuthenticateRequest(req, TrustedProxies("10.0.0.0/33"))
+	require.Error(t, err)
+
+	// Development instances pass the dev browser token along.
+	req = newDocumentRequest(t, "https://example.com/",
+		&http.Cookie{Name: "__client_uat", Value: "1"},
+		&http.Cookie{Name: "__clerk_db_jwt", Value: "dvb_123"},
+	)
+	state, err = AuthenticateRequest(req, append(opts, PublishableKey("pk_test_aGFwcHktaGlwcG8tMS5jbGVyay5hY2NvdW50cy5kZXYk"))...)
+	require.NoError(t, err)
+	location, err = url.Parse(state.Headers.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "happy-hippo-1.clerk.accounts.dev", location.Host)
+	require.Equal(t, "dvb_123", location.Query().Get("__clerk_db_jwt"))
+
+	// Requests that are not for documents can't be redirected.
+	req = httptest.NewRequest(http.MethodGet, "https://example.com/api", nil)
+	req.Header.Set("Accept", "application/json")
+	req.AddCookie(&http.Cookie{Name: "__client_uat", Value: "1"})
+	state, err = AuthenticateRequest(req, opts...)
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusSignedOut, state.Status)
+	require.Equal(t, AuthReasonSessionTokenMissing, state.Reason)
+
+	// The handshake needs the publishable key.
+	_, opts = newTestSigner(t)
+	req = newDocumentRequest(t, "https://example.com/", &http.Cookie{Name: "__client_uat", Value: "1"})
+	_, err = AuthenticateRequest(req, opts[0])
+	require.Error(t, err)
+	state, err = AuthenticateRequest(req, opts[0], ProxyURL("https://example.com/__clerk"))
+	require.NoError(t, err)
+	require.Contains(t, state.Headers.Get("Location"), "https://example.com/__clerk/v1/client/handshake?")
+}
+
+func TestAuthenticateRequest_ResolveHandshake(t *testing.T) {
+	t.Parallel()
+	sign, opts := newTestSigner(t)
+	sessionToken := sign(map[string]any{"iss": "https://clerk.example.com", "sub": "user_123"})
+	handshakeToken := sign(map[string]any{
+		"handshake": []string{
+			"__session=" + sessionToken + "; Path=/; SameSite=Lax",
+			"__client_uat=1700000000; Path=/; SameSite=Lax",
+		},
+	})
+
+	// Handshake tokens in the query are turned into cookies, with a
+	// redirect to the same URL without the token.
+	req := newDocumentRequest(t, "https://example.com/dashboard?tab=1&__clerk_handshake="+handshakeToken)
+	state, err := AuthenticateRequest(req, opts...)
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusHandshake, state.Status)
+	require.Equal(t, "https://example.com/dashboard?tab=1", state.Headers.Get("Location"))
+	require.Len(t, state.Headers.Values("Set-Cookie"), 2)
+
+	// Handshake tokens in cookies sign the request in.
+	req = newDocumentRequest(t, "https://example.com/", &http.Cookie{Name: "__clerk_handshake", Value: handshakeToken})
+	state, err = AuthenticateRequest(req, opts...)
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusSignedIn, state.Status)
+	require.Equal(t, "user_123", state.Claims.Subject)
+	require.Equal(t, sessionToken, state.Token)
+	require.Contains(t, state.Headers.Values("Set-Cookie"), "__clerk_handshake=; Path=/; Max-Age=0")
+
+	// Handshake tokens must be signed by the instance.
+	otherSign, _ := newTestSigner(t)
+	req = newDocumentRequest(t, "https://example.com/?__clerk_handshake="+otherSign(map[string]any{"handshake": []string{}}))
+	state, err = AuthenticateRequest(req, opts...)
+	require.NoError(t, err)
+	require.Equal(t, AuthStatusSignedOut, state.Status)
+	require.Equal(t, AuthReasonHandshakeTokenInvalid, state.Reason)
+}
+
+func TestWithCookieAuthorization(t *testing.T) {
+	t.Parallel()
+	sign, opts := newTestSigner(t)
+	token := sign(map[string]any{
+		"iss": "https://clerk.example.com",
+		"sub": "user_123",
+		"iat": time.Now().Unix(),
+	})
+	ts := httptest.NewServer(WithCookieAuthorization(opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := clerk.SessionClaimsFromContext(r.Context())
+		if !ok {
+			_, err := w.Write([]byte("signed out"))
+			require.NoError(t, err)
+			return
+		}
+		_, err := w.Write([]byte(claims.Subject))
+		require.NoError(t, err)
+	})))
+	defer ts.Close()
+	client := ts.Client()
+	client.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	for _, tc := range []struct {
+		name    string
+		cookies []*http.Cookie
+		status  int
+		body    string
+	}{
+		{
+			name:   "signed out",
+			status: http.StatusOK,
+			body:   "signed out",
+		},
+		{
+			name: "signed in",
+			cookies: []*http.Cookie{
+				{Name: "__session", Value: token},
+				{Name: "__client_uat", Value: "1"},
+			},
+			status: http.StatusOK,
+			body:   "user_123",
+		},
+		{
+			name:    "handshake",
+			cookies: []*http.Cookie{{Name: "__client_uat", Value: "1"}},
+			status:  http.StatusTemporaryRedirect,
+		},
+	} {
+		req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/html")
+		for _, cookie := range tc.cookies {
+			req.AddCookie(cookie)
+		}
+		res, err := client.Do(req)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.status, res.StatusCode, tc.name)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, tc.body, string(body), tc.name)
+		if tc.status == http.StatusTemporaryRedirect {
+			require.Contains(t, res.Header.Get("Location"), "https://clerk.example.com/v1/client/handshake")
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+)
+
+// Errors that describe why a Guard rejected a request. They are
+// available to the rejection handler with
+// AuthorizationErrorFromContext.
+var (
+	// ErrMissingSession means that the request has no session
+	// claims. The default rejection handler responds with 401
+	// Unauthorized.
+	ErrMissingSession = errors.New("missing session claims")
+	// ErrInsufficientAccess means that the session claims don't meet
+	// the Guard's requirements. The default rejection handler
+	// responds with 403 Forbidden.
+	ErrInsufficientAccess = errors.New("insufficient access")
+)
+
+// Guard is a middleware that lets requests through only if their
+// session claims meet its requirements.
+// Guards read the session claims from the request context, so they
+// must run after WithHeaderAuthorization or WithCookieAuthorization.
+// Guards can be combined with RequireAnyOf and RequireAllOf.
+//
+//	guard := clerkhttp.RequireAnyOf(
+//		clerkhttp.RequirePermission("org:invoices:read"),
+//		clerkhttp.RequireRole("org:admin"),
+//	)
+//	mux.Handle("/invoices", clerkhttp.WithHeaderAuthorization()(guard.Wrap(handler)))
+type Guard struct {
+	check            func(*clerk.SessionClaims) bool
+	rejectionHandler http.Handler
+}
+
+// RequirePermission returns a Guard that requires the provided
+// permission in the active organization.
+func RequirePermission(permission string) *Guard {
+	return &Guard{check: func(claims *clerk.SessionClaims) bool {
+		return claims.HasPermission(permission)
+	}}
+}
+
+// RequireRole returns a Guard that requires the provided role in the
+// active organization.
+func RequireRole(role string) *Guard {
+	return &Guard{check: func(claims *clerk.SessionClaims) bool {
+		return claims.HasRole(role)
+	}}
+}
+
+// RequireOrganization returns a Guard that requires an active
+// organization.
+func RequireOrganization() *Guard {
+	return &Guard{check: func(claims *clerk.SessionClaims) bool {
+		return claims.ActiveOrganizationID != ""
+	}}
+}
+
+// RequireAnyOf returns a Guard that requires at least one of the
+// provided guards to allow the request.
+// It panics if no guards are provided, or if any of them is nil.
+func RequireAnyOf(guards ...*Guard) *Guard {
+	mustHaveGuards("RequireAnyOf", guards)
+	return &Guard{check: func(claims *clerk.SessionClaims) bool {
+		for _, guard := range guards {
+			if guard.check(claims) {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// RequireAllOf returns a Guard that requires all of the provided
+// guards to allow the request.
+// It panics if no guards are provided, so that a missing requirement
+// doesn't allow every request, or if any of them is nil.
+func RequireAllOf(guards ...*Guard) *Guard {
+	mustHaveGuards("RequireAllOf", guards)
+	return &Guard{check: func(claims *clerk.SessionClaims) bool {
+		for _, guard := range guards {
+			if !guard.check(claims) {
+				return false
+			}
+		}
+		return true
+	}}
+}
+
+// Panics if the guards that are combined by the named function are
+// empty or contain a nil guard. Catching these at construction
+// avoids a nil pointer dereference on the first request.
+func mustHaveGuards(name string, guards []*Guard) {
+	if len(guards) == 0 {
+		panic(fmt.Sprintf("clerk: %s needs at least one guard", name))
+	}
+	for i, guard := range guards {
+		if guard == nil {
+			panic(fmt.Sprintf("clerk: %s got a nil guard at index %d", name, i))
+		}
+	}
+}
+
+// RejectionHandler returns a copy of the Guard that uses the provided
+// handler to respond to rejected requests.
+// The handler can find out why the request was rejected with
+// AuthorizationErrorFromContext. The error is either
+// ErrMissingSession or ErrInsufficientAccess.
+func (g *Guard) RejectionHandler(h http.Handler) *Guard {
+	return &Guard{check: g.check, rejectionHandler: h}
+}
+
+// Allows checks if the session claims meet the Guard's requirements.
+func (g *Guard) Allows(claims *clerk.SessionClaims) bool {
+	return claims != nil && g.check(claims)
+}
+
+// Wrap returns a handler that calls next only for requests that the
+// Guard allows.
+func (g *Guard) Wrap(next http.Handler) http.Handler {
+	rejectionHandler := g.rejectionHandler
+	if rejectionHandler == nil {
+		rejectionHandler = http.HandlerFunc(defaultGuardRejectionHandler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := clerk.SessionClaimsFromContext(r.Context())
+		var err error
+		switch {
+		case !ok || claims == nil:
+			err = ErrMissingSession
+		case !g.check(claims):
+			err = ErrInsufficientAccess
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := context.WithValue(r.Context(), authorizationErrorKey, err)
+		rejectionHandler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Responds with 401 Unauthorized for requests without a session and
+// 403 Forbidden for the rest.
+func defaultGuardRejectionHandler(w http.ResponseWriter, r *http.Request) {
+	if errors.Is(AuthorizationErrorFromContext(r.Context()), ErrMissingSession) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.WriteHeader(http.StatusForbidden)
+}
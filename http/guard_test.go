@@ -0,0 +1,142 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard(t *testing.T) {
+	t.Parallel()
+	admin := &clerk.SessionClaims{}
+	admin.ActiveOrganizationID = "org_123"
+	admin.ActiveOrganizationRole = "org:admin"
+	admin.ActiveOrganizationPermissions = []string{"org:invoices:read", "org:invoices:manage"}
+	member := &clerk.SessionClaims{}
+	member.ActiveOrganizationID = "org_123"
+	member.ActiveOrganizationRole = "org:member"
+	member.ActiveOrganizationPermissions = []string{"org:invoices:read"}
+	personal := &clerk.SessionClaims{}
+
+	for _, tc := range []struct {
+		name   string
+		guard  *Guard
+		claims *clerk.SessionClaims
+		status int
+	}{
+		{
+			name:   "permission",
+			guard:  RequirePermission("org:invoices:read"),
+			claims: member,
+			status: http.StatusOK,
+		},
+		{
+			name:   "missing permission",
+			guard:  RequirePermission("org:invoices:manage"),
+			claims: member,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "missing session",
+			guard:  RequirePermission("org:invoices:read"),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "role",
+			guard:  RequireRole("org:admin"),
+			claims: admin,
+			status: http.StatusOK,
+		},
+		{
+			name:   "organization",
+			guard:  RequireOrganization(),
+			claims: personal,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "any of",
+			guard:  RequireAnyOf(RequireRole("org:admin"), RequirePermission("org:invoices:read")),
+			claims: member,
+			status: http.StatusOK,
+		},
+		{
+			name:   "none of",
+			guard:  RequireAnyOf(RequireRole("org:admin"), RequirePermission("org:invoices:manage")),
+			claims: member,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "all of",
+			guard:  RequireAllOf(RequireOrganization(), RequirePermission("org:invoices:manage")),
+			claims: admin,
+			status: http.StatusOK,
+		},
+		{
+			name:   "not all of",
+			guard:  RequireAllOf(RequireOrganization(), RequirePermission("org:invoices:manage")),
+			claims: member,
+			status: http.StatusForbidden,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			handler := tc.guard.Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.claims != nil {
+				req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), tc.claims))
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, tc.status, rec.Code)
+			require.Equal(t, tc.status == http.StatusOK, tc.guard.Allows(tc.claims))
+		})
+	}
+}
+
+func TestGuard_NoGuards(t *testing.T) {
+	t.Parallel()
+	require.Panics(t, func() { RequireAnyOf() })
+	require.Panics(t, func() { RequireAllOf() })
+}
+
+func TestGuard_NilGuards(t *testing.T) {
+	t.Parallel()
+	var missing *Guard
+	require.PanicsWithValue(t, "clerk: RequireAnyOf got a nil guard at index 1", func() {
+		RequireAnyOf(RequireOrganization(), missing)
+	})
+	require.PanicsWithValue(t, "clerk: RequireAllOf got a nil guard at index 0", func() {
+		RequireAllOf(missing, RequireOrganization())
+	})
+}
+
+func TestGuard_RejectionHandler(t *testing.T) {
+	t.Parallel()
+	var rejectionErr error
+	guard := RequirePermission("org:invoices:read").RejectionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rejectionErr = AuthorizationErrorFromContext(r.Context())
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	handler := guard.Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.True(t, errors.Is(rejectionErr, ErrMissingSession))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), &clerk.SessionClaims{}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.True(t, errors.Is(rejectionErr, ErrInsufficientAccess))
+}
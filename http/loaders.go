@@ -0,0 +1,266 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/organization"
+	"github.com/clerk/clerk-sdk-go/v2/user"
+)
+
+// ErrMissingLoaders means that the request context has no loaders,
+// because the WithLoaders middleware didn't run.
+var ErrMissingLoaders = errors.New("missing loaders, use the WithLoaders middleware")
+
+// LoadersConfig is used to configure the WithLoaders middleware.
+type LoadersConfig struct {
+	// UserClient is used to fetch users. If it's not provided, users
+	// are fetched with the default Backend.
+	UserClient *user.Client
+	// OrganizationClient is used to fetch organizations. If it's not
+	// provided, organizations are fetched with the default Backend.
+	OrganizationClient *organization.Client
+	// Cache keeps the fetched users and organizations across
+	// requests. Optional.
+	Cache *LoaderCache
+}
+
+// WithLoaders adds loaders for the session user and the active
+// organization to the request context. The loaders fetch the user
+// and the organization the first time that UserFromContext and
+// OrganizationFromContext are called for the request, and return the
+// same result on later calls. Failed loads are not remembered, so
+// later calls try again.
+// The middleware must run after WithHeaderAuthorization or
+// WithCookieAuthorization.
+func WithLoaders(config *LoadersConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = &LoadersConfig{}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := clerk.SessionClaimsFromContext(r.Context())
+			if !ok || claims == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			l := &loaders{config: config, claims: claims}
+			ctx := context.WithValue(r.Context(), loadersKey, l)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+const loadersKey = contextKey("clerkLoaders")
+
+// UserFromContext returns the user of the active session. The user is
+// fetched once per request, unless fetching fails.
+// It returns ErrMissingSession for requests without session claims.
+func UserFromContext(ctx context.Context) (*clerk.User, error) {
+	l, err := loadersFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l.userMu.Lock()
+	defer l.userMu.Unlock()
+	if l.user == nil {
+		l.user, err = l.loadUser(ctx)
+	}
+	return l.user, err
+}
+
+// OrganizationFromContext returns the active organization of the
+// session, or nil if there's no active organization. The
+// organization is fetched once per request, unless fetching fails.
+// It returns ErrMissingSession for requests without session claims.
+func OrganizationFromContext(ctx context.Context) (*clerk.Organization, error) {
+	l, err := loadersFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if l.claims.ActiveOrganizationID == "" {
+		return nil, nil
+	}
+	l.organizationMu.Lock()
+	defer l.organizationMu.Unlock()
+	if l.organization == nil {
+		l.organization, err = l.loadOrganization(ctx)
+	}
+	return l.organization, err
+}
+
+func loadersFromContext(ctx context.Context) (*loaders, error) {
+	if l, ok := ctx.Value(loadersKey).(*loaders); ok {
+		return l, nil
+	}
+	if claims, ok := clerk.SessionClaimsFromContext(ctx); !ok || claims == nil {
+		return nil, ErrMissingSession
+	}
+	return nil, ErrMissingLoaders
+}
+
+// Request scoped loaders for the user and organization.
+type loaders struct {
+	config *LoadersConfig
+	claims *clerk.SessionClaims
+
+	// The loaded values are kept only if they were loaded
+	// successfully.
+	userMu sync.Mutex
+	user   *clerk.User
+
+	organizationMu sync.Mutex
+	organization   *clerk.Organization
+}
+
+func (l *loaders) loadUser(ctx context.Context) (*clerk.User, error) {
+	id := l.claims.Subject
+	cached := &clerk.User{}
+	if l.config.Cache.get(cacheKeyUser, id, cached) {
+		return cached, nil
+	}
+	var u *clerk.User
+	var err error
+	if l.config.UserClient != nil {
+		u, err = l.config.UserClient.Get(ctx, id)
+	} else {
+		u, err = user.Get(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	l.config.Cache.set(cacheKeyUser, id, u)
+	return u, nil
+}
+
+func (l *loaders) loadOrganization(ctx context.Context) (*clerk.Organization, error) {
+	id := l.claims.ActiveOrganizationID
+	cached := &clerk.Organization{}
+	if l.config.Cache.get(cacheKeyOrganization, id, cached) {
+		return cached, nil
+	}
+	var org *clerk.Organization
+	var err error
+	if l.config.OrganizationClient != nil {
+		org, err = l.config.OrganizationClient.Get(ctx, id)
+	} else {
+		org, err = organization.Get(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	l.config.Cache.set(cacheKeyOrganization, id, org)
+	return org, nil
+}
+
+// DefaultLoaderCacheTTL is the time that a LoaderCache keeps users
+// and organizations by default.
+const DefaultLoaderCacheTTL = time.Minute
+
+// LoaderCacheConfig is used to configure a LoaderCache.
+type LoaderCacheConfig struct {
+	// TTL is the time that users and organizations are kept in the
+	// cache. Defaults to DefaultLoaderCacheTTL.
+	TTL time.Duration
+	// Clock is used to expire cache entries. Defaults to the system
+	// clock.
+	Clock clerk.Clock
+}
+
+// LoaderCache keeps the users and organizations that the WithLoaders
+// middleware fetched, so that they can be shared across requests.
+// Keep in mind that changes to users and organizations are not
+// visible until their cache entries expire.
+// Each request gets its own copy of the cached values, so handlers
+// can modify them safely.
+type LoaderCache struct {
+	ttl   time.Duration
+	clock clerk.Clock
+
+	mu        sync.Mutex
+	entries   map[loaderCacheKey]loaderCacheEntry
+	nextSweep time.Time
+}
+
+type loaderCacheKey struct {
+	kind string
+	id   string
+}
+
+type loaderCacheEntry struct {
+	// The value in JSON, so that it can be copied.
+	value     []byte
+	expiresAt time.Time
+}
+
+const (
+	cacheKeyUser         = "user"
+	cacheKeyOrganization = "organization"
+)
+
+// NewLoaderCache returns a LoaderCache for the provided configuration.
+func NewLoaderCache(config *LoaderCacheConfig) *LoaderCache {
+	if config == nil {
+		config = &LoaderCacheConfig{}
+	}
+	c := &LoaderCache{
+		ttl:     config.TTL,
+		clock:   config.Clock,
+		entries: make(map[loaderCacheKey]loaderCacheEntry),
+	}
+	if c.ttl <= 0 {
+		c.ttl = DefaultLoaderCacheTTL
+	}
+	if c.clock == nil {
+		c.clock = clerk.NewClock()
+	}
+	return c
+}
+
+// Copies the cached value into value. It returns false if there's no
+// cached value or it's expired. It's safe to call on a nil cache.
+func (c *LoaderCache) get(kind, id string, value any) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	entry, ok := c.entries[loaderCacheKey{kind: kind, id: id}]
+	c.mu.Unlock()
+	if !ok || !c.clock.Now().Before(entry.expiresAt) {
+		return false
+	}
+	return json.Unmarshal(entry.value, value) == nil
+}
+
+// Stores a copy of the value. It's safe to call on a nil cache.
+func (c *LoaderCache) set(kind, id string, value any) {
+	if c == nil {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	// Remove expired entries once in a while, so that the cache
+	// doesn't grow with users that don't come back.
+	if !now.Before(c.nextSweep) {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+	c.entries[loaderCacheKey{kind: kind, id: id}] = loaderCacheEntry{
+		value:     data,
+		expiresAt: now.Add(c.ttl),
+	}
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/clerk/clerk-sdk-go/v2/organization"
+	"github.com/clerk/clerk-sdk-go/v2/user"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoadersConfig(t *testing.T, userFetches, organizationFetches *atomic.Int32) *LoadersConfig {
+	t.Helper()
+	clerkAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/user_123":
+			userFetches.Add(1)
+			_, err := w.Write([]byte(`{"id":"user_123","object":"user"}`))
+			require.NoError(t, err)
+		case "/organizations/org_123":
+			organizationFetches.Add(1)
+			_, err := w.Write([]byte(`{"id":"org_123","object":"organization"}`))
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(clerkAPI.Close)
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = clerkAPI.Client()
+	config.URL = &clerkAPI.URL
+	return &LoadersConfig{
+		UserClient:         user.NewClient(config),
+		OrganizationClient: organization.NewClient(config),
+	}
+}
+
+func TestWithLoaders(t *testing.T) {
+	t.Parallel()
+	var userFetches, organizationFetches atomic.Int32
+	config := newTestLoadersConfig(t, &userFetches, &organizationFetches)
+
+	// Every handler in the chain loads the user and organization.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			usr, err := UserFromContext(r.Context())
+			require.NoError(t, err)
+			require.Equal(t, "user_123", usr.ID)
+			org, err := OrganizationFromContext(r.Context())
+			require.NoError(t, err)
+			require.Equal(t, "org_123", org.ID)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	claims := &clerk.SessionClaims{}
+	claims.Subject = "user_123"
+	claims.ActiveOrganizationID = "org_123"
+
+	middleware := WithLoaders(config)
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), claims))
+		rec := httptest.NewRecorder()
+		middleware(handler).ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+	// The loaders fetch once per request.
+	require.Equal(t, int32(2), userFetches.Load())
+	require.Equal(t, int32(2), organizationFetches.Load())
+}
+
+func TestWithLoaders_Cache(t *testing.T) {
+	t.Parallel()
+	var userFetches, organizationFetches atomic.Int32
+	config := newTestLoadersConfig(t, &userFetches, &organizationFetches)
+	clock := clerktest.NewClockAt(time.Now())
+	config.Cache = NewLoaderCache(&LoaderCacheConfig{TTL: time.Minute, Clock: clock})
+
+	handler := WithLoaders(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usr, err := UserFromContext(r.Context())
+		require.NoError(t, err)
+		require.Equal(t, "user_123", usr.ID)
+		// Changes to the user don't affect other requests.
+		usr.ID = "user_456"
+		// There's no active organization.
+		org, err := OrganizationFromContext(r.Context())
+		require.NoError(t, err)
+		require.Nil(t, org)
+	}))
+	serve := func() {
+		claims := &clerk.SessionClaims{}
+		claims.Subject = "user_123"
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), claims))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve()
+	serve()
+	require.Equal(t, int32(1), userFetches.Load())
+	clock.Advance(time.Minute)
+	serve()
+	require.Equal(t, int32(2), userFetches.Load())
+	require.Equal(t, int32(0), organizationFetches.Load())
+}
+
+func TestWithLoaders_RetryAfterError(t *testing.T) {
+	t.Parallel()
+	var userFetches, organizationFetches atomic.Int32
+	config := newTestLoadersConfig(t, &userFetches, &organizationFetches)
+
+	handler := WithLoaders(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A load with a canceled context fails, but the error is not
+		// remembered.
+		ctx, cancel := context.WithCancel(r.Context())
+		cancel()
+		_, err := UserFromContext(ctx)
+		require.True(t, errors.Is(err, context.Canceled))
+		_, err = OrganizationFromContext(ctx)
+		require.True(t, errors.Is(err, context.Canceled))
+
+		usr, err := UserFromContext(r.Context())
+		require.NoError(t, err)
+		require.Equal(t, "user_123", usr.ID)
+		org, err := OrganizationFromContext(r.Context())
+		require.NoError(t, err)
+		require.Equal(t, "org_123", org.ID)
+	}))
+	claims := &clerk.SessionClaims{}
+	claims.Subject = "user_123"
+	claims.ActiveOrganizationID = "org_123"
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), claims))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, int32(1), userFetches.Load())
+	require.Equal(t, int32(1), organizationFetches.Load())
+}
+
+func TestUserFromContext_Errors(t *testing.T) {
+	t.Parallel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err := UserFromContext(req.Context())
+	require.True(t, errors.Is(err, ErrMissingSession))
+
+	ctx := clerk.ContextWithSessionClaims(req.Context(), &clerk.SessionClaims{})
+	_, err = UserFromContext(ctx)
+	require.True(t, errors.Is(err, ErrMissingLoaders))
+	_, err = OrganizationFromContext(ctx)
+	require.True(t, errors.Is(err, ErrMissingLoaders))
+}
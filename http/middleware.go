@@ -3,8 +3,10 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"strings"
 	"sync"
 	"time"
@@ -32,28 +34,19 @@ func RequireHeaderAuthorization(opts ...AuthorizationOption) func(http.Handler)
 // WithHeaderAuthorization checks the Authorization request header
 // for a valid Clerk authorization JWT. The token is parsed and verified
 // and the active session claims are written to the http.Request context.
+// For impersonated sessions, the actor is written to the context too
+// and can be retrieved with clerk.ActorFromContext.
 // The middleware uses Bearer authentication, so the Authorization header
 // is expected to have the following format:
 // Authorization: Bearer <token>
 func WithHeaderAuthorization(opts ...AuthorizationOption) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
+		cache := &middlewareCache{}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			params := &AuthorizationParams{}
-			for _, opt := range opts {
-				err := opt(params)
-				if err != nil {
-					w.WriteHeader(http.StatusUnauthorized)
-					return
-				}
-			}
-			if params.Clock == nil {
-				params.Clock = clerk.NewClock()
-			}
-			if params.AuthorizationFailureHandler == nil {
-				params.AuthorizationFailureHandler = http.HandlerFunc(defaultAuthorizationFailureHandler)
-			}
-			if params.AuthorizationJWTExtractor == nil {
-				params.AuthorizationJWTExtractor = defaultAuthorizationJWTExtractor
+			params, err := newAuthorizationParams(opts)
+			if err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
 			}
 
 			token := params.AuthorizationJWTExtractor(r)
@@ -61,71 +54,145 @@ func WithHeaderAuthorization(opts ...AuthorizationOption) func(http.Handler) htt
 				next.ServeHTTP(w, r)
 				return
 			}
-			decoded, err := jwt.Decode(r.Context(), &jwt.DecodeParams{Token: token})
+			_, err = jwt.Decode(r.Context(), &jwt.DecodeParams{Token: token})
 			if err != nil {
 				next.ServeHTTP(w, r)
 				return
 			}
-			if params.JWK == nil {
-				params.JWK, err = getJWK(r.Context(), params.JWKSClient, decoded.KeyID, params.Clock)
-				if err != nil {
-					params.AuthorizationFailureHandler.ServeHTTP(w, r)
-					return
-				}
-			}
+			cache.apply(params)
 			params.Token = token
 			claims, err := jwt.Verify(r.Context(), &params.VerifyParams)
 			if err != nil {
-				params.AuthorizationFailureHandler.ServeHTTP(w, r)
+				// Make the error available to the failure handler.
+				ctx := context.WithValue(r.Context(), authorizationErrorKey, err)
+				params.AuthorizationFailureHandler.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
 
 			// Token was verified. Add the session claims to the request context.
-			newCtx := clerk.ContextWithSessionClaims(r.Context(), claims)
-			next.ServeHTTP(w, r.WithContext(newCtx))
+			next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), claims)))
 		})
 	}
 }
 
-func defaultAuthorizationFailureHandler(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusUnauthorized)
+// Returns a new context with the session claims, and the actor if
+// the session is impersonated.
+func contextWithClaims(ctx context.Context, claims *clerk.SessionClaims) context.Context {
+	ctx = clerk.ContextWithSessionClaims(ctx, claims)
+	if actor, err := claims.ActorClaims(); err == nil && actor != nil {
+		ctx = clerk.ContextWithActor(ctx, actor)
+	}
+	return ctx
 }
 
-func defaultAuthorizationJWTExtractor(r *http.Request) string {
-	authorization := strings.TrimSpace(r.Header.Get("Authorization"))
-	return strings.TrimPrefix(authorization, "Bearer ")
+// Applies the options to new AuthorizationParams and sets defaults
+// for the ones that were not provided.
+func newAuthorizationParams(opts []AuthorizationOption) (*AuthorizationParams, error) {
+	params := &AuthorizationParams{}
+	for _, opt := range opts {
+		err := opt(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if params.Clock == nil {
+		params.Clock = clerk.NewClock()
+	}
+	if params.AuthorizationFailureHandler == nil {
+		params.AuthorizationFailureHandler = http.HandlerFunc(defaultAuthorizationFailureHandler)
+	}
+	if params.AuthorizationJWTExtractor == nil {
+		params.AuthorizationJWTExtractor = defaultAuthorizationJWTExtractor
+	}
+	return params, nil
 }
 
-// Retrieve the JSON web key for the provided token from the JWKS set.
-// Tries a cached value first, but if there's no value or the entry
-// has expired, it will fetch the JWK set from the API and cache the
-// value.
-func getJWK(ctx context.Context, jwksClient *jwks.Client, kid string, clock clerk.Clock) (*clerk.JSONWebKey, error) {
-	if kid == "" {
-		return nil, fmt.Errorf("missing jwt kid header claim")
-	}
+// Unless a JWKSCache is provided, each middleware keeps its own
+// cache.
+type middlewareCache struct {
+	once  sync.Once
+	cache *jwks.Cache
+}
 
-	jwk := getCache().Get(kid)
-	if jwk == nil || !getCache().IsValid(kid, clock.Now().UTC()) {
-		var err error
-		jwk, err = jwt.GetJSONWebKey(ctx, &jwt.GetJSONWebKeyParams{
-			KeyID:      kid,
-			JWKSClient: jwksClient,
+// Sets the middleware's cache on the params, unless they already
+// have a JWK or a JWKSCache.
+func (c *middlewareCache) apply(params *AuthorizationParams) {
+	if params.JWK != nil || params.JWKSCache != nil {
+		return
+	}
+	c.once.Do(func() {
+		c.cache = jwks.NewCache(&jwks.CacheConfig{
+			Client: params.JWKSClient,
+			Clock:  params.Clock,
 		})
-		if err != nil {
-			return nil, err
-		}
+	})
+	params.JWKSCache = c.cache
+}
+
+type contextKey string
+
+const authorizationErrorKey = contextKey("clerkAuthorizationError")
+
+// AuthorizationErrorFromContext returns the error that caused the
+// request authorization to fail, or nil.
+// The error is available to the AuthorizationFailureHandler and can
+// be matched against the jwt package errors, like jwt.ErrTokenExpired,
+// with errors.Is.
+func AuthorizationErrorFromContext(ctx context.Context) error {
+	err, _ := ctx.Value(authorizationErrorKey).(error)
+	return err
+}
+
+// Responds with 401 Unauthorized and a WWW-Authenticate header with
+// the invalid_token error code, as described in RFC 6750.
+func defaultAuthorizationFailureHandler(w http.ResponseWriter, r *http.Request) {
+	challenge := `Bearer error="invalid_token"`
+	if description := bearerErrorDescription(AuthorizationErrorFromContext(r.Context())); description != "" {
+		challenge += `, error_description="` + description + `"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.WriteHeader(http.StatusUnauthorized)
+}
+
+// Returns a description of the verification error that's safe to
+// send to clients.
+func bearerErrorDescription(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "The token is expired"
+	case errors.Is(err, jwt.ErrTokenNotYetValid):
+		return "The token is not valid yet"
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return "The token signature is invalid"
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return "The token is malformed"
+	case errors.Is(err, jwt.ErrKeyNotFound):
+		return "The token signing key is unknown"
+	case errors.Is(err, jwt.ErrInvalidAlgorithm):
+		return "The token signing algorithm is not accepted"
+	case errors.Is(err, jwt.ErrInvalidIssuer):
+		return "The token issuer is not accepted"
+	case errors.Is(err, jwt.ErrInvalidAudience):
+		return "The token audience is not accepted"
+	case errors.Is(err, jwt.ErrInvalidAuthorizedParty):
+		return "The token authorized party is not accepted"
+	case errors.Is(err, jwt.ErrInvalidActor):
+		return "Impersonated sessions are not accepted"
 	}
-	getCache().Set(kid, jwk, clock.Now().UTC().Add(time.Hour))
-	return jwk, nil
+	return ""
+}
+
+func defaultAuthorizationJWTExtractor(r *http.Request) string {
+	authorization := strings.TrimSpace(r.Header.Get("Authorization"))
+	return strings.TrimPrefix(authorization, "Bearer ")
 }
 
 type AuthorizationParams struct {
 	jwt.VerifyParams
 	// AuthorizationFailureHandler gets executed when request authorization
 	// fails. Pass a custom http.Handler to control the http.Response for
-	// invalid authorization. The default is a Response with an empty body
-	// and 401 Unauthorized status.
+	// invalid authorization. The default is a Response with an empty body,
+	// 401 Unauthorized status and a WWW-Authenticate header.
 	AuthorizationFailureHandler http.Handler
 	// JWKSClient is the jwks.Client that will be used to fetch the
 	// JSON Web Key Set. A default client will be used if none is
@@ -134,6 +201,11 @@ type AuthorizationParams struct {
 	// AuthorizationJWTExtractor is a custom function to extract the Clerk
 	// authorization JWT from the http.Request.
 	AuthorizationJWTExtractor func(r *http.Request) string
+	// TrustedProxies are the networks of the reverse proxies whose
+	// X-Forwarded-Proto and X-Forwarded-Host headers are used to
+	// build the handshake redirect URL. The headers are ignored for
+	// requests from other addresses.
+	TrustedProxies []netip.Prefix
 }
 
 // AuthorizationOption is a functional parameter for configuring
@@ -151,8 +223,11 @@ func AuthorizationJWTExtractor(extractor func(r *http.Request) string) Authoriza
 
 // AuthorizationFailureHandler allows to provide a handler that
 // writes the response in case of authorization failures.
-// The default behavior is a response with an empty body and 401
-// Unauthorized status.
+// The handler can find out why authorization failed with
+// AuthorizationErrorFromContext.
+// The default behavior is a response with an empty body, 401
+// Unauthorized status and a WWW-Authenticate header that describes
+// the error.
 func AuthorizationFailureHandler(h http.Handler) AuthorizationOption {
 	return func(params *AuthorizationParams) error {
 		params.AuthorizationFailureHandler = h
@@ -191,6 +266,40 @@ func AuthorizedPartyMatches(parties ...string) AuthorizationOption {
 	}
 }
 
+// RejectImpersonation will fail authorization for tokens of
+// impersonated sessions. Use it on sensitive routes that users must
+// access themselves.
+func RejectImpersonation() AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		params.ActorValidator = func(_ *clerk.ActorClaims) bool {
+			return false
+		}
+		return nil
+	}
+}
+
+// AllowedActors will fail authorization for tokens of impersonated
+// sessions, unless every actor is one of the provided user IDs.
+// Tokens of sessions that are not impersonated are not affected.
+func AllowedActors(actorIDs ...string) AuthorizationOption {
+	allowed := make(map[string]struct{})
+	for _, id := range actorIDs {
+		allowed[id] = struct{}{}
+	}
+
+	return func(params *AuthorizationParams) error {
+		params.ActorValidator = func(actor *clerk.ActorClaims) bool {
+			for _, sub := range actor.Subjects() {
+				if _, ok := allowed[sub]; !ok {
+					return false
+				}
+			}
+			return true
+		}
+		return nil
+	}
+}
+
 // Clock allows to pass a clock implementation that will be the
 // authority for time related operations.
 // You can use a custom clock for testing purposes, or to
@@ -230,6 +339,24 @@ func CustomClaimsConstructor(constructor func(context.Context) any) Authorizatio
 	}
 }
 
+// CustomClaims configures the middleware to parse custom token
+// claims into a value of type T. It's a typed alternative to the
+// CustomClaimsConstructor option.
+// The claims can be accessed with clerk.SessionClaimsFromContextAs.
+//
+//	WithHeaderAuthorization(CustomClaims[MyCustomClaims]())
+//
+//	// In the HTTP handler.
+//	claims, customClaims, ok := clerk.SessionClaimsFromContextAs[MyCustomClaims](r.Context())
+func CustomClaims[T any]() AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		params.CustomClaimsConstructor = func(_ context.Context) any {
+			return new(T)
+		}
+		return nil
+	}
+}
+
 // Leeway allows to set a custom leeway when comparing time values
 // for JWT verification.
 // The leeway gives some extra time to the token. That is, if the
@@ -253,8 +380,94 @@ func ProxyURL(proxyURL string) AuthorizationOption {
 	}
 }
 
+// TrustedProxies can be used to set the IP addresses or CIDR ranges
+// of the reverse proxies in front of the server, like "10.0.0.0/8".
+// The X-Forwarded-Proto and X-Forwarded-Host headers of requests from
+// these addresses are used to find the URL that the client
+// requested, for the handshake redirect. By default, the headers are
+// ignored, since any client can set them.
+func TrustedProxies(proxies ...string) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		for _, proxy := range proxies {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				addr, addrErr := netip.ParseAddr(proxy)
+				if addrErr != nil {
+					return fmt.Errorf("clerk: invalid trusted proxy %q: %w", proxy, err)
+				}
+				prefix = netip.PrefixFrom(addr, addr.BitLen())
+			}
+			params.TrustedProxies = append(params.TrustedProxies, prefix.Masked())
+		}
+		return nil
+	}
+}
+
+// PublishableKey can be used to set the Clerk publishable key for
+// the instance. The session token issuer will be validated against
+// the Frontend API URL that is encoded in the publishable key.
+func PublishableKey(key string) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		if _, err := clerk.ParsePublishableKey(key); err != nil {
+			return err
+		}
+		params.PublishableKey = clerk.String(key)
+		return nil
+	}
+}
+
+// EnvConfig applies the configuration that was read with
+// clerk.ConfigFromEnv. The CLERK_JWT_KEY is used as the JSONWebKey
+// and the CLERK_PUBLISHABLE_KEY as the PublishableKey, if they were
+// set. Options that come after EnvConfig take precedence.
+func EnvConfig(config *clerk.EnvConfig) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		if config == nil {
+			return nil
+		}
+		if config.JWK != nil {
+			params.JWK = config.JWK
+		}
+		if config.PublishableKey != nil {
+			params.PublishableKey = clerk.String(config.PublishableKey.Key)
+		}
+		return nil
+	}
+}
+
+// Issuers can be used to set the accepted values for the token
+// issuer, like the Frontend API URLs of the instance's custom
+// domains.
+func Issuers(issuers ...string) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		params.Issuers = issuers
+		return nil
+	}
+}
+
+// IssuerValidator allows to provide a handler that validates the
+// 'iss' claim. The handler should return false if the issuer is not
+// accepted. It takes precedence over the Issuers, ProxyURL and
+// PublishableKey options.
+func IssuerValidator(validator func(string) bool) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		params.IssuerValidator = validator
+		return nil
+	}
+}
+
+// Audience can be used to set the accepted values for the 'aud'
+// claim. The token must have at least one of them in its audience.
+func Audience(audience ...string) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		params.Audience = audience
+		return nil
+	}
+}
+
 // Satellite can be used to signify that the authorization happens
-// on a satellite domain.
+// on a satellite domain. Satellite domains validate the issuer only
+// if the Issuers or IssuerValidator options are provided.
 // See https://clerk.com/docs/advanced-usage/satellite-domains
 func Satellite(isSatellite bool) AuthorizationOption {
 	return func(params *AuthorizationParams) error {
@@ -263,11 +476,22 @@ func Satellite(isSatellite bool) AuthorizationOption {
 	}
 }
 
+// Algorithms allows to restrict the signing algorithms that the
+// authorization JWT can be signed with. See jwt.DefaultAlgorithms
+// for the algorithms that are accepted by default.
+func Algorithms(algs ...string) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		params.Algorithms = algs
+		return nil
+	}
+}
+
 // JSONWebKey allows to provide a custom JSON Web Key (JWK) based on
 // which the authorization JWT will be verified.
+// The key must be a PEM encoded RSA, ECDSA or Ed25519 public key.
 // When verifying the authorization JWT without a custom key, the JWK
-// will be fetched from the Clerk API and cached for one hour, then
-// the JWK will be fetched again from the Clerk API.
+// will be fetched from the Clerk API and cached. See the JWKSCache
+// option.
 // Passing a custom JSON Web Key means that no request to fetch JSON
 // web keys will be made. It's the caller's responsibility to refresh
 // the JWK when keys are rolled.
@@ -301,64 +525,16 @@ func JWKSClient(client *jwks.Client) AuthorizationOption {
 	}
 }
 
-// A cache to store JSON Web Keys.
-type jwkCache struct {
-	mu      sync.RWMutex
-	entries map[string]*cacheEntry
-}
-
-// Each entry in the JWK cache has a value and an expiration date.
-type cacheEntry struct {
-	value     *clerk.JSONWebKey
-	expiresAt time.Time
-}
-
-// IsValid returns true if a non-expired entry exists in the cache
-// for the provided key, false otherwise.
-func (c *jwkCache) IsValid(key string, t time.Time) bool {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	entry, ok := c.entries[key]
-	return ok && entry != nil && entry.expiresAt.After(t)
-}
-
-// Get fetches the JSON Web Key for the provided key, unless the
-// entry has expired.
-func (c *jwkCache) Get(key string) *clerk.JSONWebKey {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	entry, ok := c.entries[key]
-	if !ok || entry == nil {
+// JWKSCache allows to provide the jwks.Cache that holds the JSON
+// Web Key Set with which the JWT will be verified. The same cache
+// can be shared between middleware and calls to jwt.Verify.
+// Without this option, each middleware keeps its own cache, which
+// stores the JSON Web Key Set for one hour and uses the JWKSClient
+// to fetch it.
+// The JSONWebKey option takes precedence.
+func JWKSCache(cache *jwks.Cache) AuthorizationOption {
+	return func(params *AuthorizationParams) error {
+		params.JWKSCache = cache
 		return nil
 	}
-	return entry.value
-}
-
-// Set stores the JSON Web Key in the provided key and sets the
-// expiration date.
-func (c *jwkCache) Set(key string, value *clerk.JSONWebKey, expiresAt time.Time) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.entries[key] = &cacheEntry{
-		value:     value,
-		expiresAt: expiresAt,
-	}
-}
-
-var cacheInit sync.Once
-
-// A "singleton" JWK cache for the package.
-var cache *jwkCache
-
-// getCache returns the library's default cache singleton.
-// Please note that the returned Cache is a package-level variable.
-// Using the package with more than one Clerk API secret keys might
-// require to use different Clients with their own Cache.
-func getCache() *jwkCache {
-	cacheInit.Do(func() {
-		cache = &jwkCache{
-			entries: map[string]*cacheEntry{},
-		}
-	})
-	return cache
 }
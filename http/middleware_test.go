@@ -1,7 +1,11 @@
 package http
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +13,8 @@ import (
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
 	"github.com/stretchr/testify/require"
 )
 
@@ -165,6 +171,53 @@ func TestWithHeaderAuthorization_Caching(t *testing.T) {
 	require.Equal(t, 2, totalJWKSRequests)
 }
 
+func TestWithHeaderAuthorization_JWKSCache(t *testing.T) {
+	t.Parallel()
+	kid := "kid-" + t.Name()
+	totalJWKSRequests := 0
+	clerkAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/jwks" && r.Method == http.MethodGet {
+			totalJWKSRequests++
+			_, err := w.Write([]byte(
+				fmt.Sprintf(
+					`{"keys":[{"use":"sig","kty":"RSA","kid":"%s","alg":"RS256","n":"ypsS9Iq26F71B3lPjT_IMtglDXo8Dko9h5UBmrvkWo6pdH_4zmMjeghozaHY1aQf1dHUBLsov_XvG_t-1yf7tFfO_ImC1JqSQwdSjrXZp3oMNFHwdwAknvtlBg3sBxJ8nM1WaCWaTlb2JhEmczIji15UG6V0M2cAp2VK_brcylQROaJLC2zVa4usGi4AHzAHaRUTv6XB9bGYMvkM-ZniuXgp9dPurisIIWg25DGrTaH-kg8LPaqGwa54eLEnvfAe0ZH_MvA4_bn_u_iDkQ9ZI_CD1vwf0EDnzLgd9ZG1khGsqmXY_4WiLRGsPqZe90HzaBJma9sAxXB4qj_aNnwD5w","e":"AQAB"}]}`,
+					kid,
+				),
+			))
+			require.NoError(t, err)
+			return
+		}
+	}))
+	defer clerkAPI.Close()
+
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = clerkAPI.Client()
+	config.URL = &clerkAPI.URL
+	cache := jwks.NewCache(&jwks.CacheConfig{
+		Client: jwks.NewClient(config),
+	})
+
+	// Two servers that share the same cache.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte("{}"))
+		require.NoError(t, err)
+	})
+	ts1 := httptest.NewServer(WithHeaderAuthorization(JWKSCache(cache))(handler))
+	defer ts1.Close()
+	ts2 := httptest.NewServer(WithHeaderAuthorization(JWKSCache(cache))(handler))
+	defer ts2.Close()
+
+	token, _ := clerktest.GenerateJWT(t, map[string]any{"iss": "https://clerk.com"}, kid)
+	for _, ts := range []*httptest.Server{ts1, ts2} {
+		req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		_, err = ts.Client().Do(req)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, totalJWKSRequests)
+}
+
 func TestWithHeaderAuthorization_CustomFailureHandler(t *testing.T) {
 	kid := "kid-" + t.Name()
 	// Mock the Clerk API server. We expect requests to GET /jwks.
@@ -283,3 +336,160 @@ func TestAuthorizationJWTExtractor(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, http.StatusForbidden, res.StatusCode)
 }
+
+func TestWithHeaderAuthorization_FailureReasons(t *testing.T) {
+	t.Parallel()
+	clerkAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/jwks" && r.Method == http.MethodGet {
+			_, err := w.Write([]byte(`{"keys":[{"use":"sig","kty":"RSA","kid":"kid","alg":"RS256","n":"ypsS9Iq26F71B3lPjT_IMtglDXo8Dko9h5UBmrvkWo6pdH_4zmMjeghozaHY1aQf1dHUBLsov_XvG_t-1yf7tFfO_ImC1JqSQwdSjrXZp3oMNFHwdwAknvtlBg3sBxJ8nM1WaCWaTlb2JhEmczIji15UG6V0M2cAp2VK_brcylQROaJLC2zVa4usGi4AHzAHaRUTv6XB9bGYMvkM-ZniuXgp9dPurisIIWg25DGrTaH-kg8LPaqGwa54eLEnvfAe0ZH_MvA4_bn_u_iDkQ9ZI_CD1vwf0EDnzLgd9ZG1khGsqmXY_4WiLRGsPqZe90HzaBJma9sAxXB4qj_aNnwD5w","e":"AQAB"}]}`))
+			require.NoError(t, err)
+			return
+		}
+	}))
+	defer clerkAPI.Close()
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = clerkAPI.Client()
+	config.URL = &clerkAPI.URL
+	jwksClient := jwks.NewClient(config)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte("{}"))
+		require.NoError(t, err)
+	})
+
+	// The default failure handler describes the error in the
+	// WWW-Authenticate header.
+	ts := httptest.NewServer(WithHeaderAuthorization(JWKSClient(jwksClient))(handler))
+	defer ts.Close()
+	for kid, want := range map[string]string{
+		"unknown-kid": `Bearer error="invalid_token", error_description="The token signing key is unknown"`,
+		"kid":         `Bearer error="invalid_token", error_description="The token signature is invalid"`,
+	} {
+		token, _ := clerktest.GenerateJWT(t, map[string]any{"iss": "https://clerk.com"}, kid)
+		req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		require.Equal(t, want, res.Header.Get("WWW-Authenticate"))
+	}
+
+	// Custom failure handlers can access the error.
+	var failureErr error
+	ts = httptest.NewServer(WithHeaderAuthorization(
+		JWKSClient(jwksClient),
+		AuthorizationFailureHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			failureErr = AuthorizationErrorFromContext(r.Context())
+			w.WriteHeader(http.StatusTeapot)
+		})),
+	)(handler))
+	defer ts.Close()
+	token, _ := clerktest.GenerateJWT(t, map[string]any{"iss": "https://example.com"}, "kid")
+	req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTeapot, res.StatusCode)
+	require.True(t, errors.Is(failureErr, jwt.ErrTokenSignatureInvalid))
+}
+
+func TestWithHeaderAuthorization_CustomClaims(t *testing.T) {
+	t.Parallel()
+	type customClaims struct {
+		Tier string `json:"tier"`
+	}
+	token, pubKey := clerktest.GenerateJWT(t, map[string]any{
+		"iss":  "https://clerk.com",
+		"sub":  "user_123",
+		"tier": "gold",
+	}, "kid")
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	middleware := WithHeaderAuthorization(JSONWebKey(publicKey), CustomClaims[customClaims]())
+	ts := httptest.NewServer(middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, custom, ok := clerk.SessionClaimsFromContextAs[customClaims](r.Context())
+		require.True(t, ok)
+		_, err := w.Write([]byte(claims.Subject + ":" + custom.Tier))
+		require.NoError(t, err)
+	})))
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "user_123:gold", string(body))
+}
+
+func TestWithHeaderAuthorization_Actors(t *testing.T) {
+	t.Parallel()
+	token, pubKey := clerktest.GenerateJWT(t, map[string]any{
+		"iss": "https://clerk.com",
+		"sub": "user_123",
+		"act": map[string]any{"sub": "user_456"},
+	}, "kid")
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	for _, tc := range []struct {
+		name   string
+		opts   []AuthorizationOption
+		status int
+	}{
+		{
+			name:   "impersonation allowed",
+			status: http.StatusOK,
+		},
+		{
+			name:   "impersonation rejected",
+			opts:   []AuthorizationOption{RejectImpersonation()},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "allowed actor",
+			opts:   []AuthorizationOption{AllowedActors("user_456")},
+			status: http.StatusOK,
+		},
+		{
+			name:   "actor not allowed",
+			opts:   []AuthorizationOption{AllowedActors("user_789")},
+			status: http.StatusUnauthorized,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := append([]AuthorizationOption{JSONWebKey(publicKey)}, tc.opts...)
+			ts := httptest.NewServer(WithHeaderAuthorization(opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor, ok := clerk.ActorFromContext(r.Context())
+				require.True(t, ok)
+				_, err := w.Write([]byte(actor.Subject))
+				require.NoError(t, err)
+			})))
+			defer ts.Close()
+
+			req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+			res, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			require.Equal(t, tc.status, res.StatusCode)
+			if tc.status == http.StatusOK {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				require.Equal(t, "user_456", string(body))
+			} else {
+				require.Contains(t, res.Header.Get("WWW-Authenticate"), "Impersonated sessions")
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// The kinds of path segments in a routePattern, from the most to
+// the least specific.
+const (
+	segmentLiteral = iota
+	segmentWildcard
+	segmentMulti
+)
+
+type patternSegment struct {
+	kind int
+	// The literal value, or the name of a wildcard.
+	value string
+}
+
+// A request pattern with the syntax of http.ServeMux patterns since
+// Go 1.22, without host names: "[METHOD ]/path".
+type routePattern struct {
+	method   string
+	segments []patternSegment
+}
+
+func parseRoutePattern(pattern string) (*routePattern, error) {
+	method, path, found := strings.Cut(strings.TrimSpace(pattern), " ")
+	if !found {
+		method, path = "", method
+	}
+	path = strings.TrimLeft(path, " \t")
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("clerk: invalid pattern %q, path must start with a slash", pattern)
+	}
+	for _, c := range method {
+		if c < 'A' || c > 'Z' {
+			return nil, fmt.Errorf("clerk: invalid pattern %q, bad method", pattern)
+		}
+	}
+
+	p := &routePattern{method: method}
+	parts := strings.Split(path[1:], "/")
+	for i, part := range parts {
+		last := i == len(parts)-1
+		switch {
+		case last && part == "":
+			// A trailing slash matches all paths under it.
+			p.segments = append(p.segments, patternSegment{kind: segmentMulti})
+		case last && part == "{$}":
+			p.segments = append(p.segments, patternSegment{kind: segmentLiteral})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			name := part[1 : len(part)-1]
+			kind := segmentWildcard
+			if multi, ok := strings.CutSuffix(name, "..."); ok {
+				if !last {
+					return nil, fmt.Errorf("clerk: invalid pattern %q, %s must be the last segment", pattern, part)
+				}
+				name, kind = multi, segmentMulti
+			}
+			if name == "" || strings.ContainsAny(name, "{}$.") {
+				return nil, fmt.Errorf("clerk: invalid pattern %q, bad wildcard %s", pattern, part)
+			}
+			p.segments = append(p.segments, patternSegment{kind: kind, value: name})
+		case strings.ContainsAny(part, "{}"):
+			return nil, fmt.Errorf("clerk: invalid pattern %q, wildcards must be full segments", pattern)
+		default:
+			literal, err := url.PathUnescape(part)
+			if err != nil {
+				return nil, fmt.Errorf("clerk: invalid pattern %q: %w", pattern, err)
+			}
+			p.segments = append(p.segments, patternSegment{kind: segmentLiteral, value: literal})
+		}
+	}
+	return p, nil
+}
+
+func (p *routePattern) matches(method string, segments []string) bool {
+	if p.method != "" && p.method != method && !(p.method == http.MethodGet && method == http.MethodHead) {
+		return false
+	}
+	for i, segment := range p.segments {
+		if segment.kind == segmentMulti {
+			return i < len(segments)
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if segment.kind == segmentWildcard && segments[i] == "" {
+			return false
+		}
+		if segment.kind == segmentLiteral && segments[i] != segment.value {
+			return false
+		}
+	}
+	return len(segments) == len(p.segments)
+}
+
+// How the requests that two patterns match relate to each other.
+const (
+	// The patterns match the same requests.
+	patternsEqual = iota
+	// The first pattern matches a subset of the requests that the
+	// second one matches.
+	patternsMoreSpecific
+	// The first pattern matches a superset of the requests that the
+	// second one matches.
+	patternsLessSpecific
+	// No request matches both patterns.
+	patternsDisjoint
+	// Some requests match both patterns, but neither pattern is more
+	// specific. http.ServeMux calls these patterns conflicting.
+	patternsConflict
+)
+
+// Compares the requests that the patterns match, like http.ServeMux
+// does to find the most specific pattern.
+func (p *routePattern) compare(other *routePattern) int {
+	relation := patternsEqual
+	switch {
+	case p.method == other.method:
+	case other.method == "", p.method == http.MethodHead && other.method == http.MethodGet:
+		relation = patternsMoreSpecific
+	case p.method == "", p.method == http.MethodGet && other.method == http.MethodHead:
+		relation = patternsLessSpecific
+	default:
+		return patternsDisjoint
+	}
+	return combinePatternRelations(relation, p.comparePaths(other))
+}
+
+func (p *routePattern) comparePaths(other *routePattern) int {
+	relation := patternsEqual
+	for i := 0; ; i++ {
+		if i == len(p.segments) || i == len(other.segments) {
+			if len(p.segments) == len(other.segments) {
+				return relation
+			}
+			// Multi-segment wildcards match at least one segment, so
+			// paths of different lengths never match both patterns.
+			return patternsDisjoint
+		}
+		a, b := p.segments[i], other.segments[i]
+		switch {
+		case a.kind == segmentMulti && b.kind == segmentMulti:
+			return relation
+		case a.kind == segmentMulti:
+			return combinePatternRelations(relation, patternsLessSpecific)
+		case b.kind == segmentMulti:
+			return combinePatternRelations(relation, patternsMoreSpecific)
+		}
+		relation = combinePatternRelations(relation, compareSegments(a, b))
+		if relation == patternsDisjoint {
+			return relation
+		}
+	}
+}
+
+func compareSegments(a, b patternSegment) int {
+	switch {
+	case a.kind == segmentWildcard && b.kind == segmentWildcard:
+		return patternsEqual
+	case a.kind == segmentLiteral && b.kind == segmentLiteral:
+		if a.value == b.value {
+			return patternsEqual
+		}
+		return patternsDisjoint
+	}
+	// Wildcards don't match empty segments.
+	if a.value == "" && a.kind == segmentLiteral || b.value == "" && b.kind == segmentLiteral {
+		return patternsDisjoint
+	}
+	if a.kind == segmentLiteral {
+		return patternsMoreSpecific
+	}
+	return patternsLessSpecific
+}
+
+func combinePatternRelations(a, b int) int {
+	switch {
+	case a == patternsDisjoint || b == patternsDisjoint:
+		return patternsDisjoint
+	case a == patternsEqual:
+		return b
+	case b == patternsEqual || a == b:
+		return a
+	}
+	return patternsConflict
+}
+
+// Returns the segments of a request path that patterns are matched
+// against. Like http.ServeMux, the escaped path is cleaned and split
+// into segments before they are unescaped, so an escaped slash
+// ("%2F") doesn't separate segments.
+func pathSegments(escapedPath string) []string {
+	segments := strings.Split(cleanPath(escapedPath)[1:], "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	return segments
+}
+
+// Returns the segments of the request path as routers that match the
+// unescaped path see them.
+func unescapedPathSegments(escapedPath string) []string {
+	unescaped, err := url.PathUnescape(escapedPath)
+	if err != nil {
+		unescaped = escapedPath
+	}
+	return strings.Split(cleanPath(unescaped)[1:], "/")
+}
+
+// Returns the canonical path, without "." and ".." elements or
+// repeated slashes. A trailing slash is kept.
+func cleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	if p[0] != '/' {
+		p = "/" + p
+	}
+	cleaned := path.Clean(p)
+	if p[len(p)-1] == '/' && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutePattern_Matches(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		pattern string
+		method  string
+		path    string
+		want    bool
+	}{
+		{pattern: "/invoices", method: "GET", path: "/invoices", want: true},
+		{pattern: "/invoices", method: "GET", path: "/invoices/", want: false},
+		{pattern: "GET /invoices", method: "POST", path: "/invoices", want: false},
+		{pattern: "GET /invoices", method: "HEAD", path: "/invoices", want: true},
+		{pattern: "/invoices/", method: "GET", path: "/invoices/", want: true},
+		{pattern: "/invoices/", method: "GET", path: "/invoices/123/lines", want: true},
+		{pattern: "/invoices/", method: "GET", path: "/invoices", want: false},
+		{pattern: "/invoices/{$}", method: "GET", path: "/invoices/", want: true},
+		{pattern: "/invoices/{$}", method: "GET", path: "/invoices/123", want: false},
+		{pattern: "/invoices/{id}", method: "GET", path: "/invoices/123", want: true},
+		{pattern: "/invoices/{id}", method: "GET", path: "/invoices/", want: false},
+		{pattern: "/invoices/{id}", method: "GET", path: "/invoices/123/lines", want: false},
+		{pattern: "/invoices/{id}/lines", method: "GET", path: "/invoices/123/lines", want: true},
+		{pattern: "/files/{path...}", method: "GET", path: "/files/a/b/c", want: true},
+		{pattern: "/", method: "GET", path: "/anything", want: true},
+		{pattern: "/{$}", method: "GET", path: "/", want: true},
+		{pattern: "/{$}", method: "GET", path: "/anything", want: false},
+		{pattern: "/admin/", method: "GET", path: "//admin/users", want: true},
+		{pattern: "/admin/", method: "GET", path: "/admin/../invoices", want: false},
+		{pattern: "/invoices/{id}", method: "GET", path: "/invoices/./123", want: true},
+		{pattern: "/invoices/{id}", method: "GET", path: "/invoices/a%2Fb", want: true},
+		{pattern: "/invoices/a/b", method: "GET", path: "/invoices/a%2Fb", want: false},
+		{pattern: "/invoices/a%2Fb", method: "GET", path: "/invoices/a%2Fb", want: true},
+	} {
+		p, err := parseRoutePattern(tc.pattern)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, p.matches(tc.method, pathSegments(tc.path)), "%s %s %s", tc.pattern, tc.method, tc.path)
+	}
+}
+
+func TestRoutePattern_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, pattern := range []string{
+		"",
+		"invoices",
+		"get /invoices",
+		"/invoices/{id",
+		"/invoices/{}",
+		"/invoices/id-{id}",
+		"/files/{path...}/raw",
+		"/invoices/{$}/lines",
+	} {
+		_, err := parseRoutePattern(pattern)
+		require.Error(t, err, pattern)
+	}
+}
+
+func TestRoutePattern_Compare(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "/invoices/new", b: "/invoices/{id}", want: patternsMoreSpecific},
+		{a: "/invoices/{id}", b: "/invoices/", want: patternsMoreSpecific},
+		{a: "/invoices/", b: "/", want: patternsMoreSpecific},
+		{a: "/invoices/{$}", b: "/invoices/", want: patternsMoreSpecific},
+		{a: "GET /invoices/{id}", b: "/invoices/{id}", want: patternsMoreSpecific},
+		{a: "HEAD /invoices/{id}", b: "GET /invoices/{id}", want: patternsMoreSpecific},
+		{a: "/invoices/{id}/lines", b: "/invoices/{path...}", want: patternsMoreSpecific},
+		{a: "/invoices/{id}", b: "/invoices/{invoice}", want: patternsEqual},
+		{a: "/invoices/", b: "/invoices/{path...}", want: patternsEqual},
+		{a: "GET /invoices", b: "POST /invoices", want: patternsDisjoint},
+		{a: "/invoices/{id}", b: "/invoices/{id}/lines", want: patternsDisjoint},
+		{a: "/invoices/{id}", b: "/invoices/{$}", want: patternsDisjoint},
+		{a: "/invoices/{id}", b: "/{section}/new", want: patternsConflict},
+		{a: "GET /invoices/", b: "/invoices/{id}", want: patternsConflict},
+	} {
+		a, err := parseRoutePattern(tc.a)
+		require.NoError(t, err)
+		b, err := parseRoutePattern(tc.b)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, a.compare(b), "%s %s", tc.a, tc.b)
+
+		reverse := tc.want
+		switch tc.want {
+		case patternsMoreSpecific:
+			reverse = patternsLessSpecific
+		case patternsLessSpecific:
+			reverse = patternsMoreSpecific
+		}
+		require.Equal(t, reverse, b.compare(a), "%s %s", tc.b, tc.a)
+	}
+}
@@ -0,0 +1,321 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+)
+
+// PolicyRule describes the requirements for requests that match its
+// Pattern.
+// Unless the rule is Public, requests need session claims that meet
+// all of the requirements. Permissions and Features must all be
+// present, while one of the Roles and one of the Plans is enough.
+type PolicyRule struct {
+	// Pattern matches the request method and path, with the syntax of
+	// http.ServeMux patterns since Go 1.22, e.g. "GET /invoices/{id}".
+	// The method is optional, and a GET pattern also matches HEAD
+	// requests. Patterns that end with a slash match all paths under
+	// them, unless they end with "{$}". Host names are not supported.
+	Pattern string `json:"pattern"`
+	// Public allows requests without session claims.
+	Public bool `json:"public,omitempty"`
+	// Organization requires an active organization.
+	Organization bool `json:"organization,omitempty"`
+	// Permissions are required in the active organization.
+	Permissions []string `json:"permissions,omitempty"`
+	// Roles are accepted in the active organization.
+	Roles []string `json:"roles,omitempty"`
+	// Features are required, as checked by SessionClaims.HasFeature.
+	Features []string `json:"features,omitempty"`
+	// Plans are accepted, as checked by SessionClaims.HasPlan.
+	Plans []string `json:"plans,omitempty"`
+
+	pattern *routePattern
+}
+
+// PolicyConfig is used to configure a Policy. It's the format of
+// policy files.
+//
+//	{
+//		"rules": [
+//			{"pattern": "GET /health", "public": true},
+//			{"pattern": "GET /invoices/{id}", "permissions": ["org:invoices:read"]},
+//			{"pattern": "/admin/", "roles": ["org:admin"]}
+//		]
+//	}
+type PolicyConfig struct {
+	// Rules that requests are evaluated against. When more than one
+	// rule matches a request, the one with the most specific pattern
+	// applies.
+	Rules []PolicyRule `json:"rules"`
+	// DefaultAllow allows requests that don't match any rule. By
+	// default, they are denied.
+	DefaultAllow bool `json:"default_allow,omitempty"`
+	// Explain makes the default rejection handler describe the
+	// decision in the response body. Useful during development,
+	// but it exposes the policy to clients.
+	Explain bool `json:"explain,omitempty"`
+}
+
+// Policy authorizes requests with rules that map method and path
+// patterns to requirements for the session claims.
+// Like a Guard, a Policy must run after WithHeaderAuthorization or
+// WithCookieAuthorization.
+type Policy struct {
+	rules            []*PolicyRule
+	defaultAllow     bool
+	explain          bool
+	rejectionHandler http.Handler
+}
+
+// NewPolicy returns a Policy with the rules of the configuration.
+// It returns an error if a pattern is invalid or if two patterns
+// match the same requests. Like http.ServeMux, it also returns an
+// error for patterns that conflict, because some requests match both
+// and neither is more specific, e.g. "/invoices/{id}" and
+// "/{section}/new".
+func NewPolicy(config *PolicyConfig) (*Policy, error) {
+	if config == nil {
+		config = &PolicyConfig{}
+	}
+	policy := &Policy{
+		defaultAllow: config.DefaultAllow,
+		explain:      config.Explain,
+	}
+	for i := range config.Rules {
+		rule := config.Rules[i]
+		pattern, err := parseRoutePattern(rule.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range policy.rules {
+			switch pattern.compare(other.pattern) {
+			case patternsEqual:
+				return nil, fmt.Errorf("clerk: duplicate policy pattern %q", rule.Pattern)
+			case patternsConflict:
+				return nil, fmt.Errorf("clerk: policy pattern %q conflicts with %q", rule.Pattern, other.Pattern)
+			}
+		}
+		rule.pattern = pattern
+		policy.rules = append(policy.rules, &rule)
+	}
+	return policy, nil
+}
+
+// LoadPolicy reads a PolicyConfig in JSON format and returns the
+// Policy. Unknown fields are rejected, so that misspelled
+// requirements don't go unnoticed.
+func LoadPolicy(r io.Reader) (*Policy, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	config := &PolicyConfig{}
+	err := decoder.Decode(config)
+	if err != nil {
+		return nil, fmt.Errorf("clerk: invalid policy: %w", err)
+	}
+	return NewPolicy(config)
+}
+
+// LoadPolicyFile reads the policy file at the provided path. See
+// LoadPolicy for details.
+func LoadPolicyFile(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadPolicy(f)
+}
+
+// PolicyDecision explains the outcome of evaluating a request against
+// a Policy.
+type PolicyDecision struct {
+	// Allowed is true if the request is authorized.
+	Allowed bool
+	// Rule is the rule that matched the request, or nil if no rule
+	// matched.
+	Rule *PolicyRule
+	// Missing lists the requirements that the session claims don't
+	// meet, like "permission org:invoices:read".
+	Missing []string
+	// Err is ErrMissingSession or ErrInsufficientAccess for requests
+	// that are not allowed.
+	Err error
+}
+
+// String describes the decision.
+func (d *PolicyDecision) String() string {
+	rule := "no rule"
+	if d.Rule != nil {
+		rule = fmt.Sprintf("rule %q", d.Rule.Pattern)
+	}
+	switch {
+	case d.Allowed:
+		return "allowed by " + rule
+	case len(d.Missing) > 0:
+		return fmt.Sprintf("denied by %s: missing %s", rule, strings.Join(d.Missing, ", "))
+	case errors.Is(d.Err, ErrMissingSession):
+		return fmt.Sprintf("denied by %s: missing session", rule)
+	}
+	return "denied by " + rule
+}
+
+// Evaluate checks a request with the provided method and escaped
+// path, as returned by url.URL.EscapedPath, against the Policy. The
+// claims can be nil for requests without a session.
+// The path is cleaned before it's matched, so "/admin/../invoices"
+// and "//invoices" are evaluated as "/invoices". An escaped slash
+// ("%2F") doesn't separate path segments, as in http.ServeMux. Since
+// routers that match the unescaped path would see more segments, a
+// request with escaped slashes must be allowed with both kinds of
+// segments.
+func (p *Policy) Evaluate(method, path string, claims *clerk.SessionClaims) *PolicyDecision {
+	decision := p.evaluate(method, pathSegments(path), claims)
+	if !decision.Allowed || !strings.Contains(strings.ToUpper(path), "%2F") {
+		return decision
+	}
+	if unescaped := p.evaluate(method, unescapedPathSegments(path), claims); !unescaped.Allowed {
+		return unescaped
+	}
+	return decision
+}
+
+func (p *Policy) evaluate(method string, segments []string, claims *clerk.SessionClaims) *PolicyDecision {
+	var rule *PolicyRule
+	for _, r := range p.rules {
+		if !r.pattern.matches(method, segments) {
+			continue
+		}
+		// NewPolicy rejects conflicting patterns, so one of the
+		// matching rules is always more specific than the others.
+		if rule == nil || r.pattern.compare(rule.pattern) == patternsMoreSpecific {
+			rule = r
+		}
+	}
+
+	decision := &PolicyDecision{Rule: rule}
+	switch {
+	case rule == nil && p.defaultAllow, rule != nil && rule.Public:
+		decision.Allowed = true
+	case rule == nil:
+		decision.Err = ErrInsufficientAccess
+		if claims == nil {
+			decision.Err = ErrMissingSession
+		}
+	case claims == nil:
+		decision.Err = ErrMissingSession
+	default:
+		decision.Missing = rule.missing(claims)
+		decision.Allowed = len(decision.Missing) == 0
+		if !decision.Allowed {
+			decision.Err = ErrInsufficientAccess
+		}
+	}
+	return decision
+}
+
+// Explain evaluates the request against the Policy, with the session
+// claims from the request context.
+func (p *Policy) Explain(r *http.Request) *PolicyDecision {
+	claims, _ := clerk.SessionClaimsFromContext(r.Context())
+	return p.Evaluate(r.Method, r.URL.EscapedPath(), claims)
+}
+
+// RejectionHandler returns a copy of the Policy that uses the provided
+// handler to respond to rejected requests.
+// The handler can find out why the request was rejected with
+// PolicyDecisionFromContext and AuthorizationErrorFromContext.
+func (p *Policy) RejectionHandler(h http.Handler) *Policy {
+	policy := *p
+	policy.rejectionHandler = h
+	return &policy
+}
+
+// Wrap returns a handler that calls next only for requests that the
+// Policy allows. The PolicyDecision is available in the request
+// context.
+// By default, rejected requests get a 401 Unauthorized response if
+// they have no session and a 403 Forbidden response otherwise.
+func (p *Policy) Wrap(next http.Handler) http.Handler {
+	rejectionHandler := p.rejectionHandler
+	if rejectionHandler == nil {
+		rejectionHandler = http.HandlerFunc(p.defaultRejectionHandler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision := p.Explain(r)
+		ctx := context.WithValue(r.Context(), policyDecisionKey, decision)
+		if decision.Allowed {
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		ctx = context.WithValue(ctx, authorizationErrorKey, decision.Err)
+		rejectionHandler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (p *Policy) defaultRejectionHandler(w http.ResponseWriter, r *http.Request) {
+	if !p.explain {
+		defaultGuardRejectionHandler(w, r)
+		return
+	}
+	status := http.StatusForbidden
+	if errors.Is(AuthorizationErrorFromContext(r.Context()), ErrMissingSession) {
+		status = http.StatusUnauthorized
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	if decision := PolicyDecisionFromContext(r.Context()); decision != nil {
+		_, _ = io.WriteString(w, decision.String())
+	}
+}
+
+const policyDecisionKey = contextKey("clerkPolicyDecision")
+
+// PolicyDecisionFromContext returns the decision of the Policy for the
+// request, or nil.
+func PolicyDecisionFromContext(ctx context.Context) *PolicyDecision {
+	decision, _ := ctx.Value(policyDecisionKey).(*PolicyDecision)
+	return decision
+}
+
+// Returns the requirements of the rule that the claims don't meet.
+func (rule *PolicyRule) missing(claims *clerk.SessionClaims) []string {
+	var missing []string
+	if rule.Organization && claims.ActiveOrganizationID == "" {
+		missing = append(missing, "organization")
+	}
+	for _, permission := range rule.Permissions {
+		if !claims.HasPermission(permission) {
+			missing = append(missing, "permission "+permission)
+		}
+	}
+	if len(rule.Roles) > 0 && !hasAny(rule.Roles, claims.HasRole) {
+		missing = append(missing, "role "+strings.Join(rule.Roles, " or "))
+	}
+	for _, feature := range rule.Features {
+		if !claims.HasFeature(feature) {
+			missing = append(missing, "feature "+feature)
+		}
+	}
+	if len(rule.Plans) > 0 && !hasAny(rule.Plans, claims.HasPlan) {
+		missing = append(missing, "plan "+strings.Join(rule.Plans, " or "))
+	}
+	return missing
+}
+
+func hasAny(values []string, has func(string) bool) bool {
+	for _, value := range values {
+		if has(value) {
+			return true
+		}
+	}
+	return false
+}
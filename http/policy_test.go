@@ -0,0 +1,230 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `{
+	"rules": [
+		{"pattern": "GET /health", "public": true},
+		{"pattern": "/invoices/", "organization": true},
+		{"pattern": "GET /invoices/{id}", "permissions": ["org:invoices:read"]},
+		{"pattern": "DELETE /invoices/{id}", "permissions": ["org:invoices:manage"], "roles": ["org:admin", "org:billing"]},
+		{"pattern": "/reports/", "features": ["org:reports"], "plans": ["org:pro", "org:enterprise"]}
+	]
+}`
+
+func TestPolicy_Evaluate(t *testing.T) {
+	t.Parallel()
+	policy, err := LoadPolicy(strings.NewReader(testPolicy))
+	require.NoError(t, err)
+
+	member := &clerk.SessionClaims{}
+	member.ActiveOrganizationID = "org_123"
+	member.ActiveOrganizationRole = "org:member"
+	member.ActiveOrganizationPermissions = []string{"org:invoices:read"}
+	member.Features = []string{"o:reports"}
+	member.Plans = []string{"o:free"}
+	personal := &clerk.SessionClaims{}
+
+	for _, tc := range []struct {
+		name    string
+		method  string
+		path    string
+		claims  *clerk.SessionClaims
+		allowed bool
+		rule    string
+		missing []string
+		err     error
+	}{
+		{
+			name:    "public rule",
+			method:  http.MethodGet,
+			path:    "/health",
+			allowed: true,
+			rule:    "GET /health",
+		},
+		{
+			name:   "no rule",
+			method: http.MethodGet,
+			path:   "/settings",
+			claims: member,
+			err:    ErrInsufficientAccess,
+		},
+		{
+			name:   "no session",
+			method: http.MethodGet,
+			path:   "/invoices/123",
+			rule:   "GET /invoices/{id}",
+			err:    ErrMissingSession,
+		},
+		{
+			name:    "most specific rule",
+			method:  http.MethodGet,
+			path:    "/invoices/123",
+			claims:  member,
+			allowed: true,
+			rule:    "GET /invoices/{id}",
+		},
+		{
+			name:    "prefix rule",
+			method:  http.MethodPost,
+			path:    "/invoices/",
+			claims:  personal,
+			rule:    "/invoices/",
+			missing: []string{"organization"},
+			err:     ErrInsufficientAccess,
+		},
+		{
+			name:    "missing permission and role",
+			method:  http.MethodDelete,
+			path:    "/invoices/123",
+			claims:  member,
+			rule:    "DELETE /invoices/{id}",
+			missing: []string{"permission org:invoices:manage", "role org:admin or org:billing"},
+			err:     ErrInsufficientAccess,
+		},
+		{
+			name:    "missing plan",
+			method:  http.MethodGet,
+			path:    "/reports/monthly",
+			claims:  member,
+			rule:    "/reports/",
+			missing: []string{"plan org:pro or org:enterprise"},
+			err:     ErrInsufficientAccess,
+		},
+	} {
+		decision := policy.Evaluate(tc.method, tc.path, tc.claims)
+		require.Equal(t, tc.allowed, decision.Allowed, tc.name)
+		if tc.rule == "" {
+			require.Nil(t, decision.Rule, tc.name)
+		} else {
+			require.Equal(t, tc.rule, decision.Rule.Pattern, tc.name)
+		}
+		require.Equal(t, tc.missing, decision.Missing, tc.name)
+		require.True(t, errors.Is(decision.Err, tc.err), tc.name)
+	}
+
+	policy, err = NewPolicy(&PolicyConfig{DefaultAllow: true})
+	require.NoError(t, err)
+	require.True(t, policy.Evaluate(http.MethodGet, "/settings", nil).Allowed)
+}
+
+func TestPolicy_Evaluate_PathNormalization(t *testing.T) {
+	t.Parallel()
+	policy, err := NewPolicy(&PolicyConfig{
+		Rules: []PolicyRule{
+			{Pattern: "/admin/", Roles: []string{"org:admin"}},
+			{Pattern: "/x", Public: true},
+			{Pattern: "/files/{name}", Public: true},
+		},
+		DefaultAllow: true,
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		path    string
+		allowed bool
+		rule    string
+	}{
+		{path: "//admin/users", rule: "/admin/"},
+		{path: "/x/../admin/users", rule: "/admin/"},
+		{path: "/admin/../x", allowed: true, rule: "/x"},
+		{path: "/./x", allowed: true, rule: "/x"},
+		// Routers that unescape the path first see /files/a/b.
+		{path: "/files/a%2Fb", allowed: true, rule: "/files/{name}"},
+		// Routers that unescape the path first see /admin/users.
+		{path: "/admin%2Fusers", rule: "/admin/"},
+		{path: "/admin%2fusers", rule: "/admin/"},
+	} {
+		decision := policy.Evaluate(http.MethodGet, tc.path, &clerk.SessionClaims{})
+		require.Equal(t, tc.allowed, decision.Allowed, tc.path)
+		require.NotNil(t, decision.Rule, tc.path)
+		require.Equal(t, tc.rule, decision.Rule.Pattern, tc.path)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin%2Fusers", nil)
+	req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), &clerk.SessionClaims{}))
+	require.False(t, policy.Explain(req).Allowed)
+}
+
+func TestPolicy_Wrap(t *testing.T) {
+	t.Parallel()
+	policy, err := NewPolicy(&PolicyConfig{
+		Rules: []PolicyRule{
+			{Pattern: "GET /invoices/{id}", Permissions: []string{"org:invoices:read"}},
+		},
+		Explain: true,
+	})
+	require.NoError(t, err)
+	handler := policy.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision := PolicyDecisionFromContext(r.Context())
+		require.NotNil(t, decision)
+		_, err := w.Write([]byte(decision.String()))
+		require.NoError(t, err)
+	}))
+
+	for _, tc := range []struct {
+		claims *clerk.SessionClaims
+		status int
+		body   string
+	}{
+		{
+			status: http.StatusUnauthorized,
+			body:   `denied by rule "GET /invoices/{id}": missing session`,
+		},
+		{
+			claims: &clerk.SessionClaims{},
+			status: http.StatusForbidden,
+			body:   `denied by rule "GET /invoices/{id}": missing permission org:invoices:read`,
+		},
+		{
+			claims: &clerk.SessionClaims{Claims: clerk.Claims{ActiveOrganizationPermissions: []string{"org:invoices:read"}}},
+			status: http.StatusOK,
+			body:   `allowed by rule "GET /invoices/{id}"`,
+		},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/invoices/123", nil)
+		if tc.claims != nil {
+			req = req.WithContext(clerk.ContextWithSessionClaims(req.Context(), tc.claims))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, tc.status, rec.Code)
+		body, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		require.Equal(t, tc.body, string(body))
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(testPolicy), 0o600))
+	policy, err := LoadPolicyFile(path)
+	require.NoError(t, err)
+	require.Len(t, policy.rules, 5)
+
+	for _, invalid := range []string{
+		`{"rules": [{"pattern": "/invoices", "permission": ["org:invoices:read"]}]}`,
+		`{"rules": [{"pattern": "invoices"}]}`,
+		`{"rules": [{"pattern": "/invoices/{id}"}, {"pattern": "/invoices/{invoice}"}]}`,
+		`{"rules": [{"pattern": "/invoices/{id}"}, {"pattern": "/{section}/new"}]}`,
+		`{"rules": [{"pattern": "GET /invoices/"}, {"pattern": "/invoices/{id}"}]}`,
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(invalid), 0o600))
+		_, err = LoadPolicyFile(path)
+		require.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+)
+
+// Headers that the Frontend API expects from proxies.
+const (
+	proxyURLHeader  = "Clerk-Proxy-Url"
+	secretKeyHeader = "Clerk-Secret-Key"
+)
+
+// FrontendAPIProxyConfig is used to configure a FrontendAPIProxy.
+type FrontendAPIProxyConfig struct {
+	// ProxyURL is the public URL that the proxy is served at, like
+	// https://example.com/__clerk. Required.
+	// Requests are forwarded without the ProxyURL path.
+	ProxyURL string
+	// PublishableKey is the Clerk publishable key of the instance.
+	// The Frontend API URL is decoded from it. Defaults to the
+	// CLERK_PUBLISHABLE_KEY environment variable.
+	PublishableKey string
+	// FrontendAPIURL is the URL of the Frontend API that requests are
+	// forwarded to. It takes precedence over the PublishableKey.
+	FrontendAPIURL string
+	// SecretKey is the Clerk secret key of the instance. Defaults to
+	// the CLERK_SECRET_KEY environment variable.
+	SecretKey string
+	// Transport is the http.RoundTripper for forwarding requests.
+	// Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// FrontendAPIProxy is an http.Handler that proxies the Clerk Frontend
+// API through your own domain.
+// Mount it on the path of the ProxyURL and pass the same ProxyURL to
+// the Clerk frontend SDKs and the authorization middleware.
+//
+//	proxy, err := clerkhttp.NewFrontendAPIProxy(&clerkhttp.FrontendAPIProxyConfig{
+//		ProxyURL: "https://example.com/__clerk",
+//	})
+//	mux.Handle("/__clerk/", proxy)
+type FrontendAPIProxy struct {
+	proxy          *httputil.ReverseProxy
+	proxyURL       *url.URL
+	frontendAPIURL *url.URL
+	secretKey      string
+}
+
+// NewFrontendAPIProxy returns a FrontendAPIProxy for the provided
+// configuration.
+func NewFrontendAPIProxy(config *FrontendAPIProxyConfig) (*FrontendAPIProxy, error) {
+	if config == nil {
+		config = &FrontendAPIProxyConfig{}
+	}
+	proxyURL, err := url.Parse(config.ProxyURL)
+	if err != nil || !proxyURL.IsAbs() {
+		return nil, fmt.Errorf("clerk: invalid proxy URL %q", config.ProxyURL)
+	}
+
+	frontendAPI := config.FrontendAPIURL
+	if frontendAPI == "" {
+		publishableKey := config.PublishableKey
+		if publishableKey == "" {
+			publishableKey = os.Getenv(clerk.EnvPublishableKey)
+		}
+		if publishableKey == "" {
+			return nil, fmt.Errorf("clerk: missing publishable key for the frontend API proxy")
+		}
+		pk, err := clerk.ParsePublishableKey(publishableKey)
+		if err != nil {
+			return nil, err
+		}
+		frontendAPI = pk.FrontendAPIURL()
+	}
+	frontendAPIURL, err := url.Parse(frontendAPI)
+	if err != nil || !frontendAPIURL.IsAbs() {
+		return nil, fmt.Errorf("clerk: invalid frontend API URL %q", frontendAPI)
+	}
+
+	secretKey := config.SecretKey
+	if secretKey == "" {
+		secretKey = os.Getenv(clerk.EnvSecretKey)
+	}
+	if secretKey == "" {
+		return nil, fmt.Errorf("clerk: missing secret key for the frontend API proxy")
+	}
+
+	p := &FrontendAPIProxy{
+		proxyURL:       proxyURL,
+		frontendAPIURL: frontendAPIURL,
+		secretKey:      secretKey,
+	}
+	p.proxy = &httputil.ReverseProxy{
+		Rewrite:        p.rewrite,
+		ModifyResponse: p.modifyResponse,
+		Transport:      config.Transport,
+		// Stream responses to the client as they arrive.
+		FlushInterval: -1,
+	}
+	return p, nil
+}
+
+// ServeHTTP forwards the request to the Frontend API.
+func (p *FrontendAPIProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.proxy.ServeHTTP(w, r)
+}
+
+func (p *FrontendAPIProxy) rewrite(r *httputil.ProxyRequest) {
+	r.Out.URL.Path = strings.TrimPrefix(r.In.URL.Path, strings.TrimSuffix(p.proxyURL.Path, "/"))
+	r.Out.URL.RawPath = ""
+	r.SetURL(p.frontendAPIURL)
+	r.SetXForwarded()
+	r.Out.Header.Set(proxyURLHeader, p.proxyURL.String())
+	r.Out.Header.Set(secretKeyHeader, p.secretKey)
+}
+
+// Rewrites redirects and cookies of the Frontend API to the proxy.
+func (p *FrontendAPIProxy) modifyResponse(res *http.Response) error {
+	if location := res.Header.Get("Location"); location != "" {
+		res.Header.Set("Location", p.rewriteLocation(location))
+	}
+	cookies := res.Header.Values("Set-Cookie")
+	for i, cookie := range cookies {
+		cookies[i] = p.rewriteCookieDomain(cookie)
+	}
+	return nil
+}
+
+// Points redirects to the Frontend API to the proxy instead.
+func (p *FrontendAPIProxy) rewriteLocation(location string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+	if u.IsAbs() && u.Host != p.frontendAPIURL.Host {
+		return location
+	}
+	if !u.IsAbs() && !strings.HasPrefix(u.Path, "/") {
+		// Relative paths resolve to the proxy already.
+		return location
+	}
+	u.Scheme = p.proxyURL.Scheme
+	u.Host = p.proxyURL.Host
+	u.Path = strings.TrimSuffix(p.proxyURL.Path, "/") + strings.TrimPrefix(u.Path, strings.TrimSuffix(p.frontendAPIURL.Path, "/"))
+	u.RawPath = ""
+	return u.String()
+}
+
+// Replaces the Domain attribute of a Set-Cookie header value, if it's
+// the Frontend API domain, with the proxy domain.
+func (p *FrontendAPIProxy) rewriteCookieDomain(cookie string) string {
+	attributes := strings.Split(cookie, ";")
+	for i, attribute := range attributes {
+		name, value, ok := strings.Cut(strings.TrimSpace(attribute), "=")
+		if !ok || !strings.EqualFold(name, "Domain") {
+			continue
+		}
+		if strings.EqualFold(strings.TrimPrefix(value, "."), p.frontendAPIURL.Hostname()) {
+			attributes[i] = " Domain=" + p.proxyURL.Hostname()
+		}
+	}
+	return strings.Join(attributes, ";")
+}
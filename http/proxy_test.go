@@ -0,0 +1,81 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontendAPIProxy(t *testing.T) {
+	t.Parallel()
+	var frontendAPIURL *url.URL
+	frontendAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/client", r.URL.Path)
+		require.Equal(t, "_clerk_js_version=5", r.URL.RawQuery)
+		require.Equal(t, frontendAPIURL.Host, r.Host)
+		require.Equal(t, "https://example.com/__clerk", r.Header.Get("Clerk-Proxy-Url"))
+		require.Equal(t, "sk_test_123", r.Header.Get("Clerk-Secret-Key"))
+		require.NotEmpty(t, r.Header.Get("X-Forwarded-For"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "strategy=password", string(body))
+
+		w.Header().Set("Location", frontendAPIURL.String()+"/v1/client/handshake?redirect_url=https%3A%2F%2Fexample.com")
+		w.Header().Add("Set-Cookie", "__client=abc; Path=/; Domain="+frontendAPIURL.Hostname()+"; HttpOnly; Secure")
+		w.Header().Add("Set-Cookie", "__other=def; Path=/; Domain=other.com")
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	}))
+	defer frontendAPI.Close()
+	var err error
+	frontendAPIURL, err = url.Parse(frontendAPI.URL)
+	require.NoError(t, err)
+
+	proxy, err := NewFrontendAPIProxy(&FrontendAPIProxyConfig{
+		ProxyURL:       "https://example.com/__clerk",
+		FrontendAPIURL: frontendAPI.URL,
+		SecretKey:      "sk_test_123",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/__clerk/v1/client?_clerk_js_version=5", strings.NewReader("strategy=password"))
+	req.Header.Set("Clerk-Secret-Key", "sk_test_forged")
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, req)
+	res := rec.Result()
+	require.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	require.Equal(t, "https://example.com/__clerk/v1/client/handshake?redirect_url=https%3A%2F%2Fexample.com", res.Header.Get("Location"))
+	require.Equal(t, []string{
+		"__client=abc; Path=/; Domain=example.com; HttpOnly; Secure",
+		"__other=def; Path=/; Domain=other.com",
+	}, res.Header.Values("Set-Cookie"))
+}
+
+func TestNewFrontendAPIProxy_Config(t *testing.T) {
+	t.Parallel()
+	proxy, err := NewFrontendAPIProxy(&FrontendAPIProxyConfig{
+		ProxyURL:       "https://example.com/__clerk",
+		PublishableKey: "pk_live_Y2xlcmsuZXhhbXBsZS5jb20k",
+		SecretKey:      "sk_live_123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://clerk.example.com", proxy.frontendAPIURL.String())
+
+	_, err = NewFrontendAPIProxy(&FrontendAPIProxyConfig{
+		ProxyURL:       "/__clerk",
+		PublishableKey: "pk_live_Y2xlcmsuZXhhbXBsZS5jb20k",
+		SecretKey:      "sk_live_123",
+	})
+	require.Error(t, err)
+
+	_, err = NewFrontendAPIProxy(&FrontendAPIProxyConfig{
+		ProxyURL:       "https://example.com/__clerk",
+		PublishableKey: "invalid",
+		SecretKey:      "sk_live_123",
+	})
+	require.Error(t, err)
+}
@@ -9,22 +9,26 @@ import (
 )
 
 // Update updates the instance's settings.
-func Update(ctx context.Context, params *UpdateParams) error {
-	return getClient().Update(ctx, params)
+func Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) error {
+	return getClient(ctx).Update(ctx, params, opts...)
 }
 
 // UpdateRestrictions updates the restriction settings of the instance.
-func UpdateRestrictions(ctx context.Context, params *UpdateRestrictionsParams) (*clerk.InstanceRestrictions, error) {
-	return getClient().UpdateRestrictions(ctx, params)
+func UpdateRestrictions(ctx context.Context, params *UpdateRestrictionsParams, opts ...clerk.RequestOption) (*clerk.InstanceRestrictions, error) {
+	return getClient(ctx).UpdateRestrictions(ctx, params, opts...)
 }
 
 // UpdateOrganizationSettings updates the organization settings of the instance.
-func UpdateOrganizationSettings(ctx context.Context, params *UpdateOrganizationSettingsParams) (*clerk.OrganizationSettings, error) {
-	return getClient().UpdateOrganizationSettings(ctx, params)
+func UpdateOrganizationSettings(ctx context.Context, params *UpdateOrganizationSettingsParams, opts ...clerk.RequestOption) (*clerk.OrganizationSettings, error) {
+	return getClient(ctx).UpdateOrganizationSettings(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
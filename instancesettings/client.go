@@ -70,8 +70,8 @@ type UpdateParams struct {
 }
 
 // Update updates the instance's settings.
-func (c *Client) Update(ctx context.Context, params *UpdateParams) error {
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+func (c *Client) Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) error {
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	err := c.Backend.Call(ctx, req, &clerk.APIResource{})
 	return err
@@ -87,12 +87,12 @@ type UpdateRestrictionsParams struct {
 }
 
 // UpdateRestrictions updates the restriction settings of the instance.
-func (c *Client) UpdateRestrictions(ctx context.Context, params *UpdateRestrictionsParams) (*clerk.InstanceRestrictions, error) {
+func (c *Client) UpdateRestrictions(ctx context.Context, params *UpdateRestrictionsParams, opts ...clerk.RequestOption) (*clerk.InstanceRestrictions, error) {
 	path, err := clerk.JoinPath(path, "/restrictions")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	instanceRestrictions := &clerk.InstanceRestrictions{}
 	err = c.Backend.Call(ctx, req, instanceRestrictions)
@@ -111,12 +111,12 @@ type UpdateOrganizationSettingsParams struct {
 }
 
 // UpdateOrganizationSettings updates the organization settings of the instance.
-func (c *Client) UpdateOrganizationSettings(ctx context.Context, params *UpdateOrganizationSettingsParams) (*clerk.OrganizationSettings, error) {
+func (c *Client) UpdateOrganizationSettings(ctx context.Context, params *UpdateOrganizationSettingsParams, opts ...clerk.RequestOption) (*clerk.OrganizationSettings, error) {
 	path, err := clerk.JoinPath(path, "/organization_settings")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	orgSettings := &clerk.OrganizationSettings{}
 	err = c.Backend.Call(ctx, req, orgSettings)
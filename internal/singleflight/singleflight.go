@@ -0,0 +1,58 @@
+// Package singleflight provides a mechanism to suppress duplicate
+// function calls that are in flight at the same time.
+package singleflight
+
+import (
+	"context"
+	"sync"
+)
+
+// Group collapses concurrent calls with the same key into a single
+// call. The zero value is ready to use.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+// A call that's in flight.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Do executes fn, unless a call with the same key is already in
+// flight. In that case, Do waits for the call to complete and
+// returns its results.
+// The function is called with a context that is not canceled when
+// the caller's context is, so that callers that give up waiting
+// don't fail the call for everyone else. Do returns early with the
+// context error if the caller's context is done before the call
+// completes.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func(context.Context) (V, error)) (V, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = &call[V]{done: make(chan struct{})}
+		g.calls[key] = c
+		go func() {
+			c.value, c.err = fn(context.WithoutCancel(ctx))
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(c.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
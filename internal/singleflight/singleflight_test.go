@@ -0,0 +1,88 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Do(t *testing.T) {
+	t.Parallel()
+	var g Group[string, int]
+	var calls atomic.Int64
+	release := make(chan struct{})
+	fn := func(_ context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := g.Do(context.Background(), "key", fn)
+			require.NoError(t, err)
+			results[i] = v
+		}(i)
+	}
+	// Give the goroutines a chance to join the call.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int64(1), calls.Load())
+	for _, v := range results {
+		require.Equal(t, 42, v)
+	}
+
+	// Once the call completes, the next call executes fn again.
+	release = make(chan struct{})
+	close(release)
+	_, err := g.Do(context.Background(), "key", fn)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), calls.Load())
+}
+
+func TestGroup_Do_Canceled(t *testing.T) {
+	t.Parallel()
+	var g Group[string, int]
+	release := make(chan struct{})
+	errFn := errors.New("fn error")
+	fnCtx := make(chan context.Context, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := g.Do(ctx, "key", func(ctx context.Context) (int, error) {
+			fnCtx <- ctx
+			<-release
+			return 0, errFn
+		})
+		done <- err
+	}()
+
+	// The caller stops waiting when its context is canceled, but the
+	// call goes on.
+	callCtx := <-fnCtx
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	require.NoError(t, callCtx.Err())
+
+	// Other callers get the results of the call in flight.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+	_, err := g.Do(context.Background(), "key", func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	require.ErrorIs(t, err, errFn)
+}
@@ -9,22 +9,33 @@ import (
 )
 
 // List returns all invitations.
-func List(ctx context.Context, params *ListParams) (*clerk.InvitationList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.InvitationList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
+}
+
+// ListAll returns an iterator over all invitations that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Invitation] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
 }
 
 // Create adds a new identifier to the allowlist.
-func Create(ctx context.Context, params *CreateParams) (*clerk.Invitation, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.Invitation, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Revoke revokes a pending invitation.
-func Revoke(ctx context.Context, id string) (*clerk.Invitation, error) {
-	return getClient().Revoke(ctx, id)
+func Revoke(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Invitation, error) {
+	return getClient(ctx).Revoke(ctx, id, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -49,14 +49,32 @@ func (params *ListParams) ToQuery() url.Values {
 }
 
 // List returns all invitations.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.InvitationList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path))
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.InvitationList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path), opts...)
 	req.SetParams(params)
 	list := &clerk.InvitationList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
 }
 
+// ListAll returns an iterator over all invitations that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Invitation] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.Invitation, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Invitations, list.TotalCount, nil
+	})
+}
+
 type CreateParams struct {
 	clerk.APIParams
 	EmailAddress   string           `json:"email_address"`
@@ -68,8 +86,8 @@ type CreateParams struct {
 }
 
 // Create adds a new identifier to the allowlist.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Invitation, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.Invitation, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	invitation := &clerk.Invitation{}
 	err := c.Backend.Call(ctx, req, invitation)
@@ -77,12 +95,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Invit
 }
 
 // Revoke revokes a pending invitation.
-func (c *Client) Revoke(ctx context.Context, id string) (*clerk.Invitation, error) {
+func (c *Client) Revoke(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Invitation, error) {
 	path, err := clerk.JoinPath(path, id, "revoke")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	invitation := &clerk.Invitation{}
 	err = c.Backend.Call(ctx, req, invitation)
 	return invitation, err
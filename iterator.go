@@ -0,0 +1,196 @@
+package clerk
+
+import (
+	"context"
+)
+
+// defaultIteratorPageSize is the number of results that an Iterator
+// fetches with each request, unless a limit is provided.
+const defaultIteratorPageSize int64 = 100
+
+// PageFetcher retrieves a single page of results for the provided
+// ListParams. It returns the results and the total count of all
+// results.
+type PageFetcher[T any] func(ctx context.Context, params ListParams) ([]T, int64, error)
+
+// Iterator goes through all the results of a list API operation,
+// fetching pages of results lazily.
+// The ListParams Limit is used as the page size and iteration
+// starts at the ListParams Offset.
+//
+//	it := user.ListAll(ctx, &user.ListParams{})
+//	for it.Next() {
+//		usr := it.Current()
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle the error
+//	}
+//
+// Results are paginated by offset. If results are added or deleted
+// during iteration, some results might be skipped or returned twice.
+// Iteration stops when an empty or incomplete page is returned, or
+// the total count is reached.
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    PageFetcher[T]
+	limit    int64
+	offset   int64
+	prefetch bool
+
+	page    []T
+	current T
+	err     error
+	// Set when there are no more pages to fetch.
+	lastPage bool
+	// Holds the result of a prefetch request that's in flight.
+	pending chan iteratorPage[T]
+	// Closed by Close, to cancel prefetch requests.
+	closed chan struct{}
+}
+
+// The result of a single page request.
+type iteratorPage[T any] struct {
+	items      []T
+	totalCount int64
+	err        error
+}
+
+// NewIterator returns an Iterator that calls fetch to retrieve each
+// page of results.
+func NewIterator[T any](ctx context.Context, params ListParams, fetch PageFetcher[T]) *Iterator[T] {
+	it := &Iterator[T]{
+		ctx:    ctx,
+		fetch:  fetch,
+		limit:  defaultIteratorPageSize,
+		closed: make(chan struct{}),
+	}
+	if params.Limit != nil && *params.Limit > 0 {
+		it.limit = *params.Limit
+	}
+	if params.Offset != nil && *params.Offset > 0 {
+		it.offset = *params.Offset
+	}
+	return it
+}
+
+// SetPrefetch controls whether the Iterator requests the next page
+// of results in the background, while the current page is being
+// consumed.
+// It has no effect once iteration has started.
+func (it *Iterator[T]) SetPrefetch(prefetch bool) {
+	it.prefetch = prefetch
+}
+
+// Next advances the Iterator to the next result, which will be
+// available through Current. It returns false when there are no
+// more results or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || it.isClosed() {
+		return false
+	}
+	for len(it.page) == 0 {
+		if it.lastPage && it.pending == nil {
+			it.Close()
+			return false
+		}
+		if err := it.nextPage(); err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+	}
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Current returns the result that the Iterator currently points to.
+func (it *Iterator[T]) Current() T {
+	return it.current
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and cancels any page requests that are
+// in flight. It's safe to call Close multiple times.
+func (it *Iterator[T]) Close() {
+	if !it.isClosed() {
+		close(it.closed)
+	}
+}
+
+func (it *Iterator[T]) isClosed() bool {
+	select {
+	case <-it.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// All consumes the Iterator and returns all remaining results.
+func (it *Iterator[T]) All() ([]T, error) {
+	var all []T
+	for it.Next() {
+		all = append(all, it.Current())
+	}
+	return all, it.Err()
+}
+
+// Loads the next page of results, either from a prefetch request
+// that's already in flight, or by fetching the page now.
+func (it *Iterator[T]) nextPage() error {
+	var res iteratorPage[T]
+	if it.pending != nil {
+		select {
+		case res = <-it.pending:
+		case <-it.ctx.Done():
+			return it.ctx.Err()
+		}
+		it.pending = nil
+	} else {
+		if err := it.ctx.Err(); err != nil {
+			return err
+		}
+		res = it.request(it.ctx, it.offset)
+	}
+	if res.err != nil {
+		return res.err
+	}
+
+	it.page = res.items
+	it.offset += int64(len(res.items))
+	if int64(len(res.items)) < it.limit || it.offset >= res.totalCount {
+		it.lastPage = true
+	}
+	if !it.lastPage && it.prefetch {
+		it.pending = make(chan iteratorPage[T], 1)
+		go func(offset int64, pending chan<- iteratorPage[T]) {
+			// The request is canceled if the Iterator is closed
+			// before it completes.
+			ctx, cancel := context.WithCancel(it.ctx)
+			defer cancel()
+			go func() {
+				select {
+				case <-it.closed:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+			pending <- it.request(ctx, offset)
+		}(it.offset, it.pending)
+	}
+	return nil
+}
+
+// Fetches a single page of results, starting at the provided offset.
+func (it *Iterator[T]) request(ctx context.Context, offset int64) iteratorPage[T] {
+	items, totalCount, err := it.fetch(ctx, ListParams{
+		Limit:  Int64(it.limit),
+		Offset: Int64(offset),
+	})
+	return iteratorPage[T]{items: items, totalCount: totalCount, err: err}
+}
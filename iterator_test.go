@@ -0,0 +1,205 @@
+package clerk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns a PageFetcher that serves pages from the provided
+// results. Every request is recorded in the requests slice.
+func testPageFetcher(results *[]int, requests *[]ListParams, mu *sync.Mutex) PageFetcher[int] {
+	return func(_ context.Context, params ListParams) ([]int, int64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*requests = append(*requests, params)
+		all := *results
+		start := int(*params.Offset)
+		if start > len(all) {
+			start = len(all)
+		}
+		end := start + int(*params.Limit)
+		if end > len(all) {
+			end = len(all)
+		}
+		page := make([]int, end-start)
+		copy(page, all[start:end])
+		return page, int64(len(all)), nil
+	}
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+	results := []int{1, 2, 3, 4, 5}
+	var requests []ListParams
+	mu := &sync.Mutex{}
+	it := NewIterator(context.Background(), ListParams{Limit: Int64(2)}, testPageFetcher(&results, &requests, mu))
+
+	// No requests are made before iteration starts.
+	assert.Empty(t, requests)
+
+	var got []int
+	for it.Next() {
+		got = append(got, it.Current())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, results, got)
+	require.Equal(t, 3, len(requests))
+	for i, params := range requests {
+		assert.Equal(t, int64(2), *params.Limit)
+		assert.Equal(t, int64(i*2), *params.Offset)
+	}
+	// The iterator is exhausted.
+	assert.False(t, it.Next())
+}
+
+func TestIterator_Offset(t *testing.T) {
+	t.Parallel()
+	results := []int{1, 2, 3, 4, 5}
+	var requests []ListParams
+	mu := &sync.Mutex{}
+	it := NewIterator(context.Background(), ListParams{Offset: Int64(3)}, testPageFetcher(&results, &requests, mu))
+	got, err := it.All()
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, got)
+	require.Equal(t, 1, len(requests))
+	assert.Equal(t, defaultIteratorPageSize, *requests[0].Limit)
+}
+
+func TestIterator_ExactPages(t *testing.T) {
+	t.Parallel()
+	// The total count tells us that there are no more pages, so
+	// there's no need for an extra request.
+	results := []int{1, 2, 3, 4}
+	var requests []ListParams
+	mu := &sync.Mutex{}
+	it := NewIterator(context.Background(), ListParams{Limit: Int64(2)}, testPageFetcher(&results, &requests, mu))
+	got, err := it.All()
+	require.NoError(t, err)
+	assert.Equal(t, results, got)
+	assert.Equal(t, 2, len(requests))
+}
+
+func TestIterator_ResultsDeleted(t *testing.T) {
+	t.Parallel()
+	results := []int{1, 2, 3, 4, 5, 6}
+	var requests []ListParams
+	mu := &sync.Mutex{}
+	it := NewIterator(context.Background(), ListParams{Limit: Int64(2)}, testPageFetcher(&results, &requests, mu))
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+	// Delete results after the first page was consumed.
+	mu.Lock()
+	results = results[:2]
+	mu.Unlock()
+	assert.False(t, it.Next())
+	require.NoError(t, it.Err())
+}
+
+func TestIterator_ResultsAdded(t *testing.T) {
+	t.Parallel()
+	results := []int{1, 2, 3}
+	var requests []ListParams
+	mu := &sync.Mutex{}
+	it := NewIterator(context.Background(), ListParams{Limit: Int64(2)}, testPageFetcher(&results, &requests, mu))
+	var got []int
+	for it.Next() {
+		got = append(got, it.Current())
+		// Keep adding results while iterating. The iterator
+		// picks up the new results, but will eventually stop.
+		mu.Lock()
+		if len(results) < 10 {
+			results = append(results, len(results)+1)
+		}
+		mu.Unlock()
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, got)
+}
+
+func TestIterator_Error(t *testing.T) {
+	t.Parallel()
+	fetchErr := errors.New("fetch failed")
+	totalRequests := 0
+	it := NewIterator(context.Background(), ListParams{Limit: Int64(1)}, func(_ context.Context, params ListParams) ([]int, int64, error) {
+		totalRequests++
+		if *params.Offset > 0 {
+			return nil, 0, fetchErr
+		}
+		return []int{1}, 10, nil
+	})
+	got, err := it.All()
+	assert.Equal(t, []int{1}, got)
+	assert.Equal(t, fetchErr, err)
+	// The iterator stays stopped.
+	assert.False(t, it.Next())
+	assert.Equal(t, 2, totalRequests)
+}
+
+func TestIterator_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	results := []int{1, 2, 3, 4}
+	var requests []ListParams
+	mu := &sync.Mutex{}
+	it := NewIterator(ctx, ListParams{Limit: Int64(2)}, testPageFetcher(&results, &requests, mu))
+	require.True(t, it.Next())
+	cancel()
+	// The current page is still available.
+	require.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func TestIterator_Close(t *testing.T) {
+	t.Parallel()
+	canceled := make(chan struct{})
+	it := NewIterator(context.Background(), ListParams{Limit: Int64(2)}, func(ctx context.Context, params ListParams) ([]int, int64, error) {
+		if *params.Offset == 0 {
+			return []int{1, 2}, 4, nil
+		}
+		// The prefetch request waits until it's canceled.
+		<-ctx.Done()
+		close(canceled)
+		return nil, 0, ctx.Err()
+	})
+	it.SetPrefetch(true)
+	require.True(t, it.Next())
+	it.Close()
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("the prefetch request was not canceled")
+	}
+	assert.False(t, it.Next())
+	it.Close()
+}
+
+func TestIterator_Prefetch(t *testing.T) {
+	t.Parallel()
+	results := []int{1, 2, 3, 4, 5}
+	var requests []ListParams
+	mu := &sync.Mutex{}
+	it := NewIterator(context.Background(), ListParams{Limit: Int64(2)}, testPageFetcher(&results, &requests, mu))
+	it.SetPrefetch(true)
+	require.True(t, it.Next())
+	// The next page is requested as soon as the first page is loaded.
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests) == 2
+	}, time.Second, time.Millisecond)
+
+	got := []int{it.Current()}
+	for it.Next() {
+		got = append(got, it.Current())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, results, got)
+	assert.Equal(t, 3, len(requests))
+}
@@ -1,9 +1,15 @@
 package clerk
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"math/big"
 
 	"github.com/go-jose/go-jose/v3"
 )
@@ -23,6 +29,14 @@ type JSONWebKey struct {
 }
 
 func (k *JSONWebKey) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err == nil {
+		_, hasKey := fields["key"]
+		_, hasKty := fields["kty"]
+		if hasKey && !hasKty {
+			return k.unmarshalLegacyJSON(data)
+		}
+	}
 	err := k.raw.UnmarshalJSON(data)
 	if err != nil {
 		return err
@@ -31,27 +45,169 @@ func (k *JSONWebKey) UnmarshalJSON(data []byte) error {
 	k.KeyID = k.raw.KeyID
 	k.Algorithm = k.raw.Algorithm
 	k.Use = k.raw.Use
+	if k.Algorithm == "" {
+		// The alg parameter is optional. Infer it from the key type
+		// when possible.
+		k.Algorithm, _ = JSONWebKeyAlgorithm(k.Key)
+	}
 	return nil
 }
 
-// JSONWebKeyFromPEM returns a JWK from an RSA key.
+// The format that JSONWebKey values were encoded to before they
+// implemented json.Marshaler, with the key in its Go representation.
+type legacyJSONWebKey struct {
+	Key       json.RawMessage `json:"key"`
+	KeyID     string          `json:"kid"`
+	Algorithm string          `json:"alg"`
+	Use       string          `json:"use"`
+}
+
+// Decodes a key that was encoded in the legacy format, so that keys
+// which were persisted by earlier versions can still be read.
+func (k *JSONWebKey) unmarshalLegacyJSON(data []byte) error {
+	var legacy legacyJSONWebKey
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	key, err := parseLegacyKey(legacy.Key, legacy.Algorithm)
+	if err != nil {
+		return err
+	}
+	k.raw = jose.JSONWebKey{
+		Key:       key,
+		KeyID:     legacy.KeyID,
+		Algorithm: legacy.Algorithm,
+		Use:       legacy.Use,
+	}
+	k.Key = key
+	k.KeyID = legacy.KeyID
+	k.Algorithm = legacy.Algorithm
+	k.Use = legacy.Use
+	return nil
+}
+
+// Parses the Go representation of a key, as encoded by encoding/json.
+// RSA keys are encoded with their modulus and exponent, and ECDSA
+// keys with their coordinates, but without the curve, which is
+// inferred from the algorithm. Ed25519 keys and HMAC secrets are
+// encoded as byte slices.
+func parseLegacyKey(data json.RawMessage, alg string) (any, error) {
+	var secret []byte
+	if err := json.Unmarshal(data, &secret); err == nil {
+		if alg == string(jose.EdDSA) && len(secret) == ed25519.PublicKeySize {
+			return ed25519.PublicKey(secret), nil
+		}
+		return secret, nil
+	}
+	var key struct {
+		N, X, Y *big.Int
+		E       int
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	switch {
+	case key.N != nil:
+		return &rsa.PublicKey{N: key.N, E: key.E}, nil
+	case key.X != nil && key.Y != nil:
+		var curve elliptic.Curve
+		switch jose.SignatureAlgorithm(alg) {
+		case jose.ES256:
+			curve = elliptic.P256()
+		case jose.ES384:
+			curve = elliptic.P384()
+		case jose.ES512:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unknown elliptic curve for algorithm %q", alg)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: key.X, Y: key.Y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key")
+}
+
+// MarshalJSON encodes the key in the JSON Web Key format.
+func (k *JSONWebKey) MarshalJSON() ([]byte, error) {
+	return jose.JSONWebKey{
+		Key:       k.Key,
+		KeyID:     k.KeyID,
+		Algorithm: k.Algorithm,
+		Use:       k.Use,
+	}.MarshalJSON()
+}
+
+// JSONWebKeyFromPEM returns a JWK from a PEM encoded public key.
+// RSA, ECDSA and Ed25519 keys are supported. The JWK algorithm is
+// inferred from the key type.
 func JSONWebKeyFromPEM(key string) (*JSONWebKey, error) {
 	block, _ := pem.Decode([]byte(key))
 	if block == nil {
 		return nil, fmt.Errorf("invalid PEM-encoded block")
 	}
 
-	if block.Type != "PUBLIC KEY" {
+	var publicKey any
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
 		return nil, fmt.Errorf("invalid key type, expected a public key")
 	}
-
-	rsaPublicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse public key: %w", err)
 	}
 
+	alg, err := JSONWebKeyAlgorithm(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONWebKey{
+		Key:       publicKey,
+		Algorithm: alg,
+	}, nil
+}
+
+// JSONWebKeyFromSecret returns a JWK for a shared secret that is used
+// with an HMAC signing algorithm. The algorithm must be one of HS256,
+// HS384 or HS512 and tokens signed with any other algorithm will be
+// rejected.
+func JSONWebKeyFromSecret(secret []byte, algorithm string) (*JSONWebKey, error) {
+	switch jose.SignatureAlgorithm(algorithm) {
+	case jose.HS256, jose.HS384, jose.HS512:
+	default:
+		return nil, fmt.Errorf("invalid algorithm %s, expected one of HS256, HS384, HS512", algorithm)
+	}
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("missing secret")
+	}
 	return &JSONWebKey{
-		Key:       rsaPublicKey,
-		Algorithm: "RS256",
+		Key:       secret,
+		Algorithm: algorithm,
 	}, nil
 }
+
+// JSONWebKeyAlgorithm returns the default signing algorithm for the
+// provided public key.
+// RSA keys use RS256, ECDSA keys use ES256, ES384 or ES512 depending
+// on the curve and Ed25519 keys use EdDSA.
+func JSONWebKeyAlgorithm(key any) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return string(jose.RS256), nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return string(jose.ES256), nil
+		case elliptic.P384():
+			return string(jose.ES384), nil
+		case elliptic.P521():
+			return string(jose.ES512), nil
+		}
+		return "", fmt.Errorf("unsupported elliptic curve %s", k.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return string(jose.EdDSA), nil
+	}
+	return "", fmt.Errorf("unsupported public key type %T", key)
+}
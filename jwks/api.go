@@ -9,12 +9,16 @@ import (
 )
 
 // Get retrieves a JSON Web Key set.
-func Get(ctx context.Context, params *GetParams) (*clerk.JSONWebKeySet, error) {
-	return getClient().Get(ctx, params)
+func Get(ctx context.Context, params *GetParams, opts ...clerk.RequestOption) (*clerk.JSONWebKeySet, error) {
+	return getClient(ctx).Get(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -0,0 +1,547 @@
+package jwks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/internal/singleflight"
+)
+
+const (
+	// DefaultCacheTTL is the duration for which a JSON Web Key Set
+	// is cached, unless the JWKS response specifies otherwise.
+	DefaultCacheTTL = time.Hour
+	// DefaultCacheMinRefreshInterval is the minimum duration between
+	// two consecutive JSON Web Key Set fetches.
+	DefaultCacheMinRefreshInterval = time.Minute
+	// DefaultCacheStaleIfError is the duration for which an expired
+	// JSON Web Key Set is used if it cannot be fetched again.
+	DefaultCacheStaleIfError = time.Hour
+	// DefaultCacheMaxSnapshotAge is the maximum age of a persisted
+	// JSON Web Key Set that a Cache will use.
+	DefaultCacheMaxSnapshotAge = 24 * time.Hour
+)
+
+// ErrKeyNotFound is returned when the JSON Web Key Set doesn't have
+// a key with the requested key ID.
+var ErrKeyNotFound = errors.New("missing json web key")
+
+// CacheConfig is used to configure a new Cache.
+type CacheConfig struct {
+	// Client is the Client that will be used to fetch the JSON Web
+	// Key Set. If it's not set, the Cache uses a Client with the
+	// Backend from the context, or the package level Backend.
+	// Key sets that are fetched with a Backend from the context are
+	// cached separately for each Clerk instance, as identified by
+	// clerk.BackendIdentity, but they are not persisted to the Store.
+	// Key sets of instances that are not used for a TTL are removed.
+	// Backends that were not created with clerk.NewBackend need a
+	// Client.
+	Client *Client
+	// TTL is the duration for which the JSON Web Key Set is cached.
+	// A max-age directive in the Cache-Control header of the JWKS
+	// response takes precedence. Defaults to DefaultCacheTTL.
+	TTL time.Duration
+	// MinRefreshInterval is the minimum duration between two
+	// consecutive fetches. It limits how often the JSON Web Key Set
+	// is fetched again because a token has an unknown key ID.
+	// Defaults to DefaultCacheMinRefreshInterval.
+	MinRefreshInterval time.Duration
+	// StaleIfError is the duration after expiry for which the JSON
+	// Web Key Set is still used, if fetching it again fails. It
+	// protects against a temporary Clerk API failure turning into an
+	// authentication outage. Defaults to DefaultCacheStaleIfError.
+	// Set a negative value to never use an expired set.
+	StaleIfError time.Duration
+	// Store persists the JSON Web Key Set every time it's fetched.
+	// The persisted set is loaded when the Cache is first used, so
+	// that tokens can be verified without fetching the set. Until
+	// the TTL passes the set is used as if it was just fetched.
+	// After that, it's used only if fetching fails, for as long as
+	// it's not older than MaxSnapshotAge.
+	Store Store
+	// MaxSnapshotAge is the maximum age of a persisted JSON Web Key
+	// Set. Older sets are ignored.
+	// Defaults to DefaultCacheMaxSnapshotAge.
+	MaxSnapshotAge time.Duration
+	// Clock is the source of time for the Cache. Defaults to the
+	// system clock.
+	Clock clerk.Clock
+}
+
+// Cache holds a JSON Web Key Set and provides the JSON Web Keys
+// for verifying session tokens.
+// The key set is fetched when it's needed for the first time and
+// is fetched again when it expires, or when a key ID that's not in
+// the set is requested.
+// Concurrent lookups that need to fetch the set share a single
+// request. If fetching fails, the expired set is used for a limited
+// time.
+// A Cache is safe for concurrent use and is meant to be shared
+// between all verifications for the same Clerk instance.
+type Cache struct {
+	client             *Client
+	ttl                time.Duration
+	minRefreshInterval time.Duration
+	staleIfError       time.Duration
+	store              Store
+	maxSnapshotAge     time.Duration
+	clock              clerk.Clock
+
+	snapshotOnce sync.Once
+	snapshotErr  error
+
+	// The key set that's fetched with the Client, or the package
+	// level Backend.
+	keys cachedKeySet
+	// The key sets that are fetched with a Backend from the context,
+	// by clerk.BackendIdentity.
+	backendsMu sync.Mutex
+	backends   map[string]*cachedKeySet
+	nextSweep  time.Time
+}
+
+// A cached JSON Web Key Set and the state of its fetches.
+type cachedKeySet struct {
+	// The Client for the Backend from the context, or nil.
+	client *Client
+	// The last time the key set was needed, for key sets of Backends
+	// from the context. Guarded by the Cache's backendsMu.
+	usedAt  time.Time
+	fetches singleflight.Group[struct{}, *clerk.JSONWebKeySet]
+
+	mu        sync.RWMutex
+	keySet    *clerk.JSONWebKeySet
+	expiresAt time.Time
+	// The set can be used until this time if fetching fails.
+	staleUntil time.Time
+	// The time of the last fetch attempt.
+	fetchedAt time.Time
+	// The error of the last fetch attempt, if it failed.
+	fetchErr error
+}
+
+// NewCache returns a new, empty Cache.
+func NewCache(config *CacheConfig) *Cache {
+	if config == nil {
+		config = &CacheConfig{}
+	}
+	c := &Cache{
+		client:             config.Client,
+		ttl:                config.TTL,
+		minRefreshInterval: config.MinRefreshInterval,
+		staleIfError:       config.StaleIfError,
+		store:              config.Store,
+		maxSnapshotAge:     config.MaxSnapshotAge,
+		clock:              config.Clock,
+	}
+	if c.ttl <= 0 {
+		c.ttl = DefaultCacheTTL
+	}
+	if c.minRefreshInterval <= 0 {
+		c.minRefreshInterval = DefaultCacheMinRefreshInterval
+	}
+	if c.staleIfError == 0 {
+		c.staleIfError = DefaultCacheStaleIfError
+	}
+	if c.maxSnapshotAge <= 0 {
+		c.maxSnapshotAge = DefaultCacheMaxSnapshotAge
+	}
+	if c.clock == nil {
+		c.clock = clerk.NewClock()
+	}
+	return c
+}
+
+// Get returns the JSON Web Key with the provided key ID.
+// The JSON Web Key Set is fetched if it's not cached or it has
+// expired. If the cached set doesn't contain the key ID, the set is
+// fetched again, at most once every MinRefreshInterval.
+func (c *Cache) Get(ctx context.Context, kid string) (*clerk.JSONWebKey, error) {
+	if kid == "" {
+		return nil, fmt.Errorf("missing jwt kid header claim")
+	}
+
+	keys, err := c.keySetFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Errors are ignored, the set will be fetched instead.
+	_ = c.LoadSnapshot(ctx)
+	keySet, ok := c.cached(keys)
+	if ok {
+		if jwk := findKey(keySet, kid); jwk != nil {
+			return jwk, nil
+		}
+		// The key might have been rotated, but don't fetch the set
+		// again too often.
+		if !c.canFetch(keys) {
+			return nil, ErrKeyNotFound
+		}
+	}
+
+	keySet, err = c.fetch(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	if jwk := findKey(keySet, kid); jwk != nil {
+		return jwk, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// KeySet returns the cached JSON Web Key Set, fetching it if it's
+// not cached or it has expired.
+func (c *Cache) KeySet(ctx context.Context) (*clerk.JSONWebKeySet, error) {
+	keys, err := c.keySetFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.LoadSnapshot(ctx)
+	if keySet, ok := c.cached(keys); ok {
+		return keySet, nil
+	}
+	return c.fetch(ctx, keys)
+}
+
+// Refresh fetches the JSON Web Key Set and replaces the cached one.
+// Unlike Get and KeySet, Refresh returns the fetch error even if
+// there's a stale set that can be used.
+func (c *Cache) Refresh(ctx context.Context) error {
+	keys, err := c.keySetFor(ctx)
+	if err != nil {
+		return err
+	}
+	return c.refresh(ctx, keys)
+}
+
+func (c *Cache) refresh(ctx context.Context, keys *cachedKeySet) error {
+	_, err := keys.fetches.Do(ctx, struct{}{}, func(ctx context.Context) (*clerk.JSONWebKeySet, error) {
+		return c.fetchKeySet(ctx, keys)
+	})
+	return err
+}
+
+// Returns the key set for the Backend in the context, or the default
+// key set if the Cache has a Client or the context has no Backend.
+func (c *Cache) keySetFor(ctx context.Context) (*cachedKeySet, error) {
+	if c.client != nil {
+		return &c.keys, nil
+	}
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		return &c.keys, nil
+	}
+	// Backends are often created for each request, so key sets are
+	// kept by instance rather than by Backend.
+	id, ok := clerk.BackendIdentity(backend)
+	if !ok {
+		return nil, errors.New("the Backend in the context was not created with clerk.NewBackend, provide a Client to the Cache")
+	}
+
+	now := c.clock.Now().UTC()
+	c.backendsMu.Lock()
+	defer c.backendsMu.Unlock()
+	// Remove the key sets of instances that are no longer used once
+	// in a while, so that the Cache doesn't grow indefinitely.
+	if !now.Before(c.nextSweep) {
+		for id, keys := range c.backends {
+			if !now.Before(keys.usedAt.Add(c.ttl)) {
+				delete(c.backends, id)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+	if c.backends == nil {
+		c.backends = make(map[string]*cachedKeySet)
+	}
+	keys, ok := c.backends[id]
+	if !ok {
+		keys = &cachedKeySet{client: &Client{Backend: backend}}
+		c.backends[id] = keys
+	}
+	keys.usedAt = now
+	return keys, nil
+}
+
+// LoadSnapshot loads the persisted JSON Web Key Set from the Store.
+// The Cache loads the snapshot when it's first used, but calling
+// LoadSnapshot at startup reports any errors. The snapshot is loaded
+// only once, subsequent calls return the same result.
+// Snapshots that are older than MaxSnapshotAge are ignored.
+func (c *Cache) LoadSnapshot(ctx context.Context) error {
+	if c.store == nil {
+		return nil
+	}
+	c.snapshotOnce.Do(func() {
+		// The result is shared with later callers, so it must not
+		// depend on the first caller's deadline or cancellation.
+		c.snapshotErr = c.loadSnapshot(context.WithoutCancel(ctx))
+	})
+	return c.snapshotErr
+}
+
+func (c *Cache) loadSnapshot(ctx context.Context) error {
+	snapshot, err := c.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil || snapshot.KeySet == nil {
+		return nil
+	}
+	maxAgeAt := snapshot.FetchedAt.Add(c.maxSnapshotAge)
+	if !c.clock.Now().UTC().Before(maxAgeAt) {
+		return nil
+	}
+
+	keys := &c.keys
+	keys.mu.Lock()
+	defer keys.mu.Unlock()
+	// The set might have been fetched in the meantime.
+	if keys.keySet != nil {
+		return nil
+	}
+	keys.keySet = snapshot.KeySet
+	keys.expiresAt = snapshot.FetchedAt.Add(c.ttl)
+	if keys.expiresAt.After(maxAgeAt) {
+		keys.expiresAt = maxAgeAt
+	}
+	keys.staleUntil = maxAgeAt
+	return nil
+}
+
+// Start refreshes the JSON Web Key Set in the background, shortly
+// before it expires, so that verifications don't need to wait for
+// the set to be fetched. Failed refreshes are retried every
+// MinRefreshInterval.
+// The key sets that were fetched with a Backend from the context are
+// refreshed too, until they are removed for not being used.
+// The background refresh runs until the context is canceled.
+func (c *Cache) Start(ctx context.Context) {
+	go func() {
+		for {
+			timer := time.NewTimer(c.nextRefresh())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			now := c.clock.Now().UTC()
+			for _, keys := range c.keySets() {
+				if c.refreshAt(keys).After(now) {
+					continue
+				}
+				// Errors are ignored, the refresh is retried on a
+				// later iteration.
+				_ = c.refresh(ctx, keys)
+			}
+		}
+	}()
+}
+
+// Returns the default key set and the key sets of the Backends from
+// the context that were used within the last TTL.
+func (c *Cache) keySets() []*cachedKeySet {
+	keySets := []*cachedKeySet{&c.keys}
+	now := c.clock.Now().UTC()
+	c.backendsMu.Lock()
+	defer c.backendsMu.Unlock()
+	for _, keys := range c.backends {
+		if now.Before(keys.usedAt.Add(c.ttl)) {
+			keySets = append(keySets, keys)
+		}
+	}
+	return keySets
+}
+
+// Returns the duration until the next background refresh of any of
+// the key sets.
+func (c *Cache) nextRefresh() time.Duration {
+	now := c.clock.Now().UTC()
+	var next time.Duration
+	for i, keys := range c.keySets() {
+		if wait := c.refreshAt(keys).Sub(now); i == 0 || wait < next {
+			next = wait
+		}
+	}
+	if next < 0 {
+		return 0
+	}
+	return next
+}
+
+// Returns the time of the next background refresh of the key set.
+// The set is refreshed when 90% of its TTL has passed, but never
+// sooner than MinRefreshInterval after the last fetch.
+func (c *Cache) refreshAt(keys *cachedKeySet) time.Time {
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+	if keys.fetchedAt.IsZero() {
+		return time.Time{}
+	}
+	refreshAt := keys.fetchedAt.Add(c.minRefreshInterval)
+	if keys.keySet != nil {
+		ttl := keys.expiresAt.Sub(keys.fetchedAt)
+		if at := keys.expiresAt.Add(-ttl / 10); at.After(refreshAt) {
+			refreshAt = at
+		}
+	}
+	return refreshAt
+}
+
+// Returns the cached JSON Web Key Set if it hasn't expired.
+// An expired set is also returned if the last fetch failed and the
+// set is within the StaleIfError period, until it's time to try
+// fetching again.
+func (c *Cache) cached(keys *cachedKeySet) (*clerk.JSONWebKeySet, bool) {
+	now := c.clock.Now().UTC()
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+	if keys.keySet == nil {
+		return nil, false
+	}
+	if now.Before(keys.expiresAt) {
+		return keys.keySet, true
+	}
+	if keys.fetchErr != nil && keys.isStaleUsable(now) &&
+		now.Sub(keys.fetchedAt) < c.minRefreshInterval {
+		return keys.keySet, true
+	}
+	return nil, false
+}
+
+// Reports whether an expired set can be used at the provided time.
+// Must be called with the lock held.
+func (keys *cachedKeySet) isStaleUsable(now time.Time) bool {
+	return now.Before(keys.staleUntil)
+}
+
+// Reports whether enough time has passed since the last fetch.
+func (c *Cache) canFetch(keys *cachedKeySet) bool {
+	now := c.clock.Now().UTC()
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+	return now.Sub(keys.fetchedAt) >= c.minRefreshInterval
+}
+
+// Fetches the JSON Web Key Set, sharing the request with concurrent
+// callers. If the fetch fails, the expired set is returned if it's
+// still usable.
+func (c *Cache) fetch(ctx context.Context, keys *cachedKeySet) (*clerk.JSONWebKeySet, error) {
+	keySet, err := keys.fetches.Do(ctx, struct{}{}, func(ctx context.Context) (*clerk.JSONWebKeySet, error) {
+		return c.fetchKeySet(ctx, keys)
+	})
+	if err == nil {
+		return keySet, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+	now := c.clock.Now().UTC()
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+	if keys.keySet != nil && keys.isStaleUsable(now) {
+		return keys.keySet, nil
+	}
+	return nil, err
+}
+
+// Fetches the JSON Web Key Set and stores it in the cache. The
+// default key set is also stored in the Store.
+func (c *Cache) fetchKeySet(ctx context.Context, keys *cachedKeySet) (*clerk.JSONWebKeySet, error) {
+	keys.mu.Lock()
+	keys.fetchedAt = c.clock.Now().UTC()
+	keys.mu.Unlock()
+
+	client := keys.client
+	if client == nil {
+		client = c.getClient(ctx)
+	}
+	keySet, err := client.Get(ctx, &GetParams{})
+	if err == nil && keySet == nil {
+		err = fmt.Errorf("no jwks found")
+	}
+	if err != nil {
+		keys.mu.Lock()
+		keys.fetchErr = err
+		keys.mu.Unlock()
+		return nil, err
+	}
+
+	ttl := c.ttl
+	if keySet.Response != nil {
+		if maxAge, ok := cacheControlMaxAge(keySet.Response.Header); ok {
+			ttl = maxAge
+		}
+	}
+	// Make sure that the set is not fetched on every verification.
+	if ttl < c.minRefreshInterval {
+		ttl = c.minRefreshInterval
+	}
+
+	now := c.clock.Now().UTC()
+	keys.mu.Lock()
+	keys.keySet = keySet
+	keys.expiresAt = now.Add(ttl)
+	keys.staleUntil = keys.expiresAt
+	if c.staleIfError > 0 {
+		keys.staleUntil = keys.expiresAt.Add(c.staleIfError)
+	}
+	keys.fetchErr = nil
+	keys.mu.Unlock()
+
+	if c.store != nil && keys == &c.keys {
+		// Failing to persist the set doesn't affect the cache.
+		_ = c.store.Save(ctx, &Snapshot{KeySet: keySet, FetchedAt: now})
+	}
+	return keySet, nil
+}
+
+func (c *Cache) getClient(ctx context.Context) *Client {
+	if c.client != nil {
+		return c.client
+	}
+	return getClient(ctx)
+}
+
+// Returns the key with the provided ID from the set, or nil.
+func findKey(keySet *clerk.JSONWebKeySet, kid string) *clerk.JSONWebKey {
+	for _, k := range keySet.Keys {
+		if k != nil && k.KeyID == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// Parses the Cache-Control header and returns for how long the
+// response can be cached. The no-store and no-cache directives
+// result in a zero duration.
+func cacheControlMaxAge(header http.Header) (time.Duration, bool) {
+	cacheControl := header.Get("Cache-Control")
+	if cacheControl == "" {
+		return 0, false
+	}
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		if directive == "no-store" || directive == "no-cache" {
+			return 0, true
+		}
+		if value, ok := strings.CutPrefix(directive, "max-age="); ok {
+			seconds, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+			if err != nil || seconds < 0 {
+				continue
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,336 @@
+package jwks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns a Client for a JWKS endpoint that responds with the provided
+// key IDs and Cache-Control header. The returned counter holds the
+// number of requests.
+func newTestCacheClient(t *testing.T, kids []string, cacheControl string) (*Client, *atomic.Int64) {
+	t.Helper()
+	newBackend, totalRequests := newTestCacheBackend(t, kids, cacheControl)
+	return &Client{Backend: newBackend()}, totalRequests
+}
+
+// Like newTestCacheClient, but returns a function that creates a new
+// Backend for the JWKS endpoint on every call.
+func newTestCacheBackend(t *testing.T, kids []string, cacheControl string) (func() clerk.Backend, *atomic.Int64) {
+	t.Helper()
+	var totalRequests atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/jwks", r.URL.Path)
+		totalRequests.Add(1)
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		keys := ""
+		for i, kid := range kids {
+			if i > 0 {
+				keys += ","
+			}
+			keys += fmt.Sprintf(`{"use":"sig","kty":"RSA","kid":"%s","alg":"RS256","n":"ypsS9Iq26F71B3lPjT_IMtglDXo8Dko9h5UBmrvkWo6pdH_4zmMjeghozaHY1aQf1dHUBLsov_XvG_t-1yf7tFfO_ImC1JqSQwdSjrXZp3oMNFHwdwAknvtlBg3sBxJ8nM1WaCWaTlb2JhEmczIji15UG6V0M2cAp2VK_brcylQROaJLC2zVa4usGi4AHzAHaRUTv6XB9bGYMvkM-ZniuXgp9dPurisIIWg25DGrTaH-kg8LPaqGwa54eLEnvfAe0ZH_MvA4_bn_u_iDkQ9ZI_CD1vwf0EDnzLgd9ZG1khGsqmXY_4WiLRGsPqZe90HzaBJma9sAxXB4qj_aNnwD5w","e":"AQAB"}`, kid)
+		}
+		_, err := w.Write([]byte(`{"keys":[` + keys + `]}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+	return func() clerk.Backend {
+		return clerk.NewBackend(&clerk.BackendConfig{
+			HTTPClient: ts.Client(),
+			URL:        clerk.String(ts.URL),
+			Key:        clerk.String("sk_test_123"),
+		})
+	}, &totalRequests
+}
+
+func TestCache_Get(t *testing.T) {
+	t.Parallel()
+	client, totalRequests := newTestCacheClient(t, []string{"kid_1"}, "")
+	clock := clerktest.NewClockAt(time.Now().UTC())
+	cache := NewCache(&CacheConfig{
+		Client: client,
+		Clock:  clock,
+	})
+	ctx := context.Background()
+
+	// The first lookup fetches the set.
+	jwk, err := cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, "kid_1", jwk.KeyID)
+	require.Equal(t, int64(1), totalRequests.Load())
+
+	// The next lookup uses the cached set.
+	_, err = cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalRequests.Load())
+
+	// Unknown key IDs trigger a fetch, but not more than once every
+	// MinRefreshInterval.
+	clock.Advance(DefaultCacheMinRefreshInterval)
+	_, err = cache.Get(ctx, "kid_2")
+	require.Error(t, err)
+	require.Equal(t, int64(2), totalRequests.Load())
+	_, err = cache.Get(ctx, "kid_2")
+	require.Error(t, err)
+	require.Equal(t, int64(2), totalRequests.Load())
+
+	// The set is fetched again after it expires.
+	clock.Advance(DefaultCacheTTL)
+	_, err = cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), totalRequests.Load())
+
+	_, err = cache.Get(ctx, "")
+	require.Error(t, err)
+}
+
+func TestCache_ContextBackends(t *testing.T) {
+	t.Parallel()
+	newBackend1, totalRequests1 := newTestCacheBackend(t, []string{"kid_1"}, "")
+	newBackend2, totalRequests2 := newTestCacheBackend(t, []string{"kid_2"}, "")
+	clock := clerktest.NewClockAt(time.Now().UTC())
+	cache := NewCache(&CacheConfig{Clock: clock})
+
+	// Each instance gets its own key set, even with a new Backend for
+	// every request.
+	for i := 0; i < 2; i++ {
+		jwk, err := cache.Get(clerk.ContextWithBackend(context.Background(), newBackend1()), "kid_1")
+		require.NoError(t, err)
+		require.Equal(t, "kid_1", jwk.KeyID)
+		jwk, err = cache.Get(clerk.ContextWithBackend(context.Background(), newBackend2()), "kid_2")
+		require.NoError(t, err)
+		require.Equal(t, "kid_2", jwk.KeyID)
+	}
+	require.Equal(t, int64(1), totalRequests1.Load())
+	require.Equal(t, int64(1), totalRequests2.Load())
+	require.Len(t, cache.backends, 2)
+
+	ctx1 := clerk.ContextWithBackend(context.Background(), newBackend1())
+	_, err := cache.Get(ctx1, "kid_2")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// Key sets of instances that are no longer used are removed.
+	clock.Advance(DefaultCacheTTL)
+	_, err = cache.Get(ctx1, "kid_1")
+	require.NoError(t, err)
+	require.Len(t, cache.backends, 1)
+
+	// Other Backends need a Client.
+	_, err = cache.Get(clerk.ContextWithBackend(context.Background(), customBackend{}), "kid_1")
+	require.Error(t, err)
+}
+
+// A Backend that was not created with clerk.NewBackend.
+type customBackend struct {
+	clerk.Backend
+}
+
+func TestCache_CacheControl(t *testing.T) {
+	t.Parallel()
+	client, totalRequests := newTestCacheClient(t, []string{"kid_1"}, "public, max-age=600")
+	clock := clerktest.NewClockAt(time.Now().UTC())
+	cache := NewCache(&CacheConfig{
+		Client: client,
+		Clock:  clock,
+	})
+	ctx := context.Background()
+
+	_, err := cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalRequests.Load())
+
+	// The max-age directive takes precedence over the TTL.
+	clock.Advance(9 * time.Minute)
+	_, err = cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalRequests.Load())
+
+	clock.Advance(2 * time.Minute)
+	_, err = cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), totalRequests.Load())
+}
+
+func TestCacheControlMaxAge(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{header: "", ok: false},
+		{header: "public", ok: false},
+		{header: "max-age=60", want: time.Minute, ok: true},
+		{header: "public, Max-Age=3600, must-revalidate", want: time.Hour, ok: true},
+		{header: "no-store", want: 0, ok: true},
+		{header: "no-cache, max-age=60", want: 0, ok: true},
+		{header: "max-age=invalid", ok: false},
+	} {
+		header := http.Header{}
+		header.Set("Cache-Control", tc.header)
+		got, ok := cacheControlMaxAge(header)
+		require.Equal(t, tc.ok, ok, tc.header)
+		require.Equal(t, tc.want, got, tc.header)
+	}
+}
+
+func TestCache_Start(t *testing.T) {
+	t.Parallel()
+	client, totalRequests := newTestCacheClient(t, []string{"kid_1"}, "")
+	cache := NewCache(&CacheConfig{
+		Client:             client,
+		TTL:                20 * time.Millisecond,
+		MinRefreshInterval: 10 * time.Millisecond,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cache.Start(ctx)
+
+	// The set is fetched immediately and refreshed before it expires.
+	require.Eventually(t, func() bool {
+		return totalRequests.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+	jwk, err := cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, "kid_1", jwk.KeyID)
+
+	// The background refresh stops when the context is canceled.
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	stopped := totalRequests.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, stopped, totalRequests.Load())
+}
+
+func TestCache_Start_ContextBackends(t *testing.T) {
+	t.Parallel()
+	newBackend, totalRequests := newTestCacheBackend(t, []string{"kid_1"}, "")
+	newDefaultBackend, _ := newTestCacheBackend(t, []string{"kid_1"}, "")
+	cache := NewCache(&CacheConfig{
+		TTL:                200 * time.Millisecond,
+		MinRefreshInterval: 10 * time.Millisecond,
+	})
+	_, err := cache.Get(clerk.ContextWithBackend(context.Background(), newBackend()), "kid_1")
+	require.NoError(t, err)
+
+	// The key set of the Backend from the context is refreshed too.
+	ctx, cancel := context.WithCancel(clerk.ContextWithBackend(context.Background(), newDefaultBackend()))
+	defer cancel()
+	cache.Start(ctx)
+	require.Eventually(t, func() bool {
+		return totalRequests.Load() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// Key sets that are not used for a TTL are no longer refreshed.
+	time.Sleep(400 * time.Millisecond)
+	require.Equal(t, int64(2), totalRequests.Load())
+}
+
+func TestCache_ConcurrentFetches(t *testing.T) {
+	t.Parallel()
+	var totalRequests atomic.Int64
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		totalRequests.Add(1)
+		<-release
+		_, err := w.Write([]byte(`{"keys":[{"use":"sig","kty":"RSA","kid":"kid_1","alg":"RS256","n":"ypsS9Iq26F71B3lPjT_IMtglDXo8Dko9h5UBmrvkWo6pdH_4zmMjeghozaHY1aQf1dHUBLsov_XvG_t-1yf7tFfO_ImC1JqSQwdSjrXZp3oMNFHwdwAknvtlBg3sBxJ8nM1WaCWaTlb2JhEmczIji15UG6V0M2cAp2VK_brcylQROaJLC2zVa4usGi4AHzAHaRUTv6XB9bGYMvkM-ZniuXgp9dPurisIIWg25DGrTaH-kg8LPaqGwa54eLEnvfAe0ZH_MvA4_bn_u_iDkQ9ZI_CD1vwf0EDnzLgd9ZG1khGsqmXY_4WiLRGsPqZe90HzaBJma9sAxXB4qj_aNnwD5w","e":"AQAB"}]}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	cache := NewCache(&CacheConfig{
+		Client: &Client{
+			Backend: clerk.NewBackend(&clerk.BackendConfig{
+				HTTPClient: ts.Client(),
+				URL:        &ts.URL,
+			}),
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jwk, err := cache.Get(context.Background(), "kid_1")
+			require.NoError(t, err)
+			require.Equal(t, "kid_1", jwk.KeyID)
+		}()
+	}
+	// Give the goroutines a chance to join the fetch.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int64(1), totalRequests.Load())
+}
+
+func TestCache_StaleIfError(t *testing.T) {
+	t.Parallel()
+	var totalRequests atomic.Int64
+	var failing atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		totalRequests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte(`{"keys":[{"use":"sig","kty":"RSA","kid":"kid_1","alg":"RS256","n":"ypsS9Iq26F71B3lPjT_IMtglDXo8Dko9h5UBmrvkWo6pdH_4zmMjeghozaHY1aQf1dHUBLsov_XvG_t-1yf7tFfO_ImC1JqSQwdSjrXZp3oMNFHwdwAknvtlBg3sBxJ8nM1WaCWaTlb2JhEmczIji15UG6V0M2cAp2VK_brcylQROaJLC2zVa4usGi4AHzAHaRUTv6XB9bGYMvkM-ZniuXgp9dPurisIIWg25DGrTaH-kg8LPaqGwa54eLEnvfAe0ZH_MvA4_bn_u_iDkQ9ZI_CD1vwf0EDnzLgd9ZG1khGsqmXY_4WiLRGsPqZe90HzaBJma9sAxXB4qj_aNnwD5w","e":"AQAB"}]}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	clock := clerktest.NewClockAt(time.Now().UTC())
+	cache := NewCache(&CacheConfig{
+		Client: &Client{
+			Backend: clerk.NewBackend(&clerk.BackendConfig{
+				HTTPClient: ts.Client(),
+				URL:        &ts.URL,
+				Retry:      &clerk.RetryConfig{MaxAttempts: 1},
+			}),
+		},
+		Clock:        clock,
+		StaleIfError: 30 * time.Minute,
+	})
+	ctx := context.Background()
+
+	_, err := cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalRequests.Load())
+
+	// The set expired and the API fails. The stale set is used.
+	failing.Store(true)
+	clock.Advance(DefaultCacheTTL + time.Minute)
+	jwk, err := cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, "kid_1", jwk.KeyID)
+	require.Equal(t, int64(2), totalRequests.Load())
+
+	// Fetching is not retried on every lookup.
+	_, err = cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), totalRequests.Load())
+
+	// The explicit refresh reports the error.
+	require.Error(t, cache.Refresh(ctx))
+	require.Equal(t, int64(3), totalRequests.Load())
+
+	// The stale set is not used after the StaleIfError period.
+	clock.Advance(30 * time.Minute)
+	_, err = cache.Get(ctx, "kid_1")
+	require.Error(t, err)
+
+	// Once the API recovers, the set is fetched again.
+	failing.Store(false)
+	clock.Advance(DefaultCacheMinRefreshInterval)
+	_, err = cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+}
@@ -28,8 +28,8 @@ type GetParams struct {
 }
 
 // Get retrieves a JSON Web Key set.
-func (c *Client) Get(ctx context.Context, params *GetParams) (*clerk.JSONWebKeySet, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+func (c *Client) Get(ctx context.Context, params *GetParams, opts ...clerk.RequestOption) (*clerk.JSONWebKeySet, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.JSONWebKeySet{}
 	err := c.Backend.Call(ctx, req, resource)
@@ -0,0 +1,83 @@
+package jwks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+)
+
+// Snapshot is a JSON Web Key Set that was persisted by a Cache,
+// along with the time it was fetched.
+type Snapshot struct {
+	KeySet    *clerk.JSONWebKeySet `json:"jwks"`
+	FetchedAt time.Time            `json:"fetched_at"`
+}
+
+// Store persists Snapshots of the JSON Web Key Set, so that a Cache
+// can use them when it starts.
+type Store interface {
+	// Load returns the stored Snapshot. It returns a nil Snapshot if
+	// nothing has been stored yet.
+	Load(context.Context) (*Snapshot, error)
+	// Save stores the Snapshot, replacing any previous one.
+	Save(context.Context, *Snapshot) error
+}
+
+// FileStore is a Store that keeps the Snapshot in a JSON file.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore returns a FileStore that keeps the Snapshot in the
+// file at the provided path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the Snapshot from the file. It returns a nil Snapshot if
+// the file does not exist.
+func (s *FileStore) Load(_ context.Context) (*Snapshot, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	err = json.Unmarshal(data, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwks snapshot %s: %w", s.path, err)
+	}
+	return snapshot, nil
+}
+
+// Save writes the Snapshot to the file. The file is replaced
+// atomically, so that a concurrent Load never reads a partial
+// Snapshot.
+func (s *FileStore) Save(_ context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
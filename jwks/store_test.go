@@ -0,0 +1,152 @@
+package jwks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/stretchr/testify/require"
+)
+
+const testJWK = `{"use":"sig","kty":"RSA","kid":"kid_1","alg":"RS256","n":"ypsS9Iq26F71B3lPjT_IMtglDXo8Dko9h5UBmrvkWo6pdH_4zmMjeghozaHY1aQf1dHUBLsov_XvG_t-1yf7tFfO_ImC1JqSQwdSjrXZp3oMNFHwdwAknvtlBg3sBxJ8nM1WaCWaTlb2JhEmczIji15UG6V0M2cAp2VK_brcylQROaJLC2zVa4usGi4AHzAHaRUTv6XB9bGYMvkM-ZniuXgp9dPurisIIWg25DGrTaH-kg8LPaqGwa54eLEnvfAe0ZH_MvA4_bn_u_iDkQ9ZI_CD1vwf0EDnzLgd9ZG1khGsqmXY_4WiLRGsPqZe90HzaBJma9sAxXB4qj_aNnwD5w","e":"AQAB"}`
+
+func testKeySet(t *testing.T) *clerk.JSONWebKeySet {
+	t.Helper()
+	keySet := &clerk.JSONWebKeySet{}
+	err := json.Unmarshal([]byte(`{"keys":[`+testJWK+`]}`), keySet)
+	require.NoError(t, err)
+	return keySet
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := NewFileStore(filepath.Join(t.TempDir(), "jwks.json"))
+
+	// Nothing has been stored yet.
+	snapshot, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
+	err = store.Save(ctx, &Snapshot{KeySet: testKeySet(t), FetchedAt: fetchedAt})
+	require.NoError(t, err)
+
+	snapshot, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, fetchedAt, snapshot.FetchedAt)
+	require.Len(t, snapshot.KeySet.Keys, 1)
+	require.Equal(t, "kid_1", snapshot.KeySet.Keys[0].KeyID)
+	require.NotNil(t, snapshot.KeySet.Keys[0].Key)
+}
+
+func TestFileStore_InvalidSnapshot(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err := NewFileStore(path).Load(context.Background())
+	require.Error(t, err)
+}
+
+func TestCache_Store(t *testing.T) {
+	t.Parallel()
+	var totalRequests atomic.Int64
+	var failing atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		totalRequests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte(`{"keys":[` + testJWK + `]}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := &Client{
+		Backend: clerk.NewBackend(&clerk.BackendConfig{
+			HTTPClient: ts.Client(),
+			URL:        &ts.URL,
+			Retry:      &clerk.RetryConfig{MaxAttempts: 1},
+		}),
+	}
+	store := NewFileStore(filepath.Join(t.TempDir(), "jwks.json"))
+	clock := clerktest.NewClockAt(time.Now().UTC())
+	ctx := context.Background()
+
+	// Fetching the set persists it.
+	cache := NewCache(&CacheConfig{Client: client, Store: store, Clock: clock})
+	_, err := cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalRequests.Load())
+	snapshot, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, clock.Now().UTC(), snapshot.FetchedAt)
+
+	// A new cache uses the persisted set without fetching it.
+	cache = NewCache(&CacheConfig{Client: client, Store: store, Clock: clock})
+	require.NoError(t, cache.LoadSnapshot(ctx))
+	jwk, err := cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, "kid_1", jwk.KeyID)
+	require.Equal(t, int64(1), totalRequests.Load())
+
+	// After the TTL, the persisted set is used only if fetching
+	// fails.
+	failing.Store(true)
+	clock.Advance(2 * DefaultCacheTTL)
+	cache = NewCache(&CacheConfig{Client: client, Store: store, Clock: clock})
+	_, err = cache.Get(ctx, "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), totalRequests.Load())
+
+	// Snapshots older than the max age are ignored.
+	cache = NewCache(&CacheConfig{
+		Client:         client,
+		Store:          store,
+		Clock:          clock,
+		MaxSnapshotAge: DefaultCacheTTL,
+	})
+	_, err = cache.Get(ctx, "kid_1")
+	require.Error(t, err)
+	require.Equal(t, int64(3), totalRequests.Load())
+}
+
+// A Store that fails when its context is done.
+type contextStore struct {
+	snapshot *Snapshot
+}
+
+func (s *contextStore) Load(ctx context.Context) (*Snapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.snapshot, nil
+}
+
+func (s *contextStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	return ctx.Err()
+}
+
+func TestCache_LoadSnapshot_CanceledContext(t *testing.T) {
+	t.Parallel()
+	clock := clerktest.NewClockAt(time.Now().UTC())
+	store := &contextStore{snapshot: &Snapshot{KeySet: testKeySet(t), FetchedAt: clock.Now().UTC()}}
+	cache := NewCache(&CacheConfig{Store: store, Clock: clock})
+
+	// The canceled context of the first caller doesn't affect the
+	// snapshot that later callers use.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, cache.LoadSnapshot(ctx))
+	jwk, err := cache.Get(context.Background(), "kid_1")
+	require.NoError(t, err)
+	require.Equal(t, "kid_1", jwk.KeyID)
+}
@@ -3,6 +3,9 @@ package clerk
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
@@ -25,6 +28,27 @@ func SessionClaimsFromContext(ctx context.Context) (*SessionClaims, bool) {
 	return claims, ok
 }
 
+// SessionClaimsFromContextAs returns the active session claims from
+// the context, along with their custom claims as type T.
+// It returns false if there are no session claims in the context,
+// or the custom claims are not of type T.
+//
+//	type MyCustomClaims struct {
+//		Tier string `json:"tier"`
+//	}
+//	claims, custom, ok := clerk.SessionClaimsFromContextAs[MyCustomClaims](ctx)
+func SessionClaimsFromContextAs[T any](ctx context.Context) (*SessionClaims, *T, bool) {
+	claims, ok := SessionClaimsFromContext(ctx)
+	if !ok || claims == nil {
+		return nil, nil, false
+	}
+	custom, ok := claims.Custom.(*T)
+	if !ok || custom == nil {
+		return claims, nil, false
+	}
+	return claims, custom, true
+}
+
 // SessionClaims represents Clerk specific JWT claims.
 type SessionClaims struct {
 	// Standard IANA JWT claims
@@ -69,6 +93,51 @@ func (s *SessionClaims) HasRole(role string) bool {
 	return s.ActiveOrganizationRole == role
 }
 
+// HasFeature checks if the session claims contain the provided
+// billing feature, either for the user or for the active
+// organization.
+// Prefix the feature with "user:" or "org:" to check only the user's
+// or the organization's features.
+func (s *SessionClaims) HasFeature(feature string) bool {
+	return hasScopedClaim(s.Features, feature)
+}
+
+// HasPlan checks if the session claims contain the provided billing
+// plan, either for the user or for the active organization.
+// Prefix the plan with "user:" or "org:" to check only the user's
+// or the organization's plans.
+func (s *SessionClaims) HasPlan(plan string) bool {
+	return hasScopedClaim(s.Plans, plan)
+}
+
+// Checks if the scoped claim values contain the name, which can
+// have a "user:" or "org:" prefix.
+func hasScopedClaim(values []string, name string) bool {
+	want := ""
+	if scope, rest, ok := strings.Cut(name, ":"); ok {
+		switch scope {
+		case "user":
+			want, name = "u", rest
+		case "org":
+			want, name = "o", rest
+		}
+	}
+	for _, value := range values {
+		scope, valueName := splitScope(value)
+		if valueName == name && strings.Contains(scope, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsImpersonated checks if the session claims belong to an
+// impersonated session, in which case the Actor claim describes who
+// is impersonating the user.
+func (s *SessionClaims) IsImpersonated() bool {
+	return hasActor(s.Actor)
+}
+
 // RegisteredClaims holds public claim values (as specified in RFC 7519).
 type RegisteredClaims struct {
 	Issuer    string   `json:"iss,omitempty"`
@@ -115,6 +184,9 @@ func (c *RegisteredClaims) ValidateWithLeeway(expected time.Time, leeway time.Du
 
 // Claims represents private JWT claims that are defined and used
 // by Clerk.
+// Version 2 session tokens carry the active organization in the
+// compact 'o' claim. Both token versions are decoded into the same
+// fields.
 type Claims struct {
 	SessionID                     string          `json:"sid"`
 	AuthorizedParty               string          `json:"azp"`
@@ -123,6 +195,100 @@ type Claims struct {
 	ActiveOrganizationRole        string          `json:"org_role"`
 	ActiveOrganizationPermissions []string        `json:"org_permissions"`
 	Actor                         json.RawMessage `json:"act,omitempty"`
+	// Version is the session token version. It's zero for version 1
+	// tokens, which don't have the 'v' claim.
+	Version int `json:"v,omitempty"`
+	// FactorVerificationAge holds the minutes that passed since the
+	// user verified their first and second factor. A value of -1
+	// means that the factor was not verified.
+	FactorVerificationAge []int `json:"fva,omitempty"`
+	// Features holds the billing features of the user and the active
+	// organization, prefixed with their scope, like "u:export" or
+	// "o:analytics". Features that belong to both have the "ou:"
+	// prefix.
+	Features []string `json:"-"`
+	// Plans holds the billing plans of the user and the active
+	// organization, prefixed with their scope like Features.
+	Plans []string `json:"-"`
+}
+
+// Version 2 session token claims for the active organization.
+type compactOrganizationClaims struct {
+	ID   string `json:"id"`
+	Slug string `json:"slg"`
+	Role string `json:"rol"`
+	// Comma separated permission names.
+	Permissions string `json:"per"`
+	// Comma separated bit masks, one for each organization feature,
+	// which specify the feature's permissions.
+	FeaturePermissionMap string `json:"fpm"`
+}
+
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	type claims Claims
+	raw := struct {
+		*claims
+		Features     string                     `json:"fea"`
+		Plans        string                     `json:"pla"`
+		Organization *compactOrganizationClaims `json:"o"`
+	}{claims: (*claims)(c)}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	c.Features = splitClaim(raw.Features)
+	c.Plans = splitClaim(raw.Plans)
+	if raw.Organization == nil {
+		return nil
+	}
+
+	c.ActiveOrganizationID = raw.Organization.ID
+	c.ActiveOrganizationSlug = raw.Organization.Slug
+	c.ActiveOrganizationRole = raw.Organization.Role
+	if c.ActiveOrganizationRole != "" && !strings.HasPrefix(c.ActiveOrganizationRole, "org:") {
+		c.ActiveOrganizationRole = "org:" + c.ActiveOrganizationRole
+	}
+	var orgFeatures []string
+	for _, feature := range c.Features {
+		scope, name := splitScope(feature)
+		if strings.Contains(scope, "o") {
+			orgFeatures = append(orgFeatures, name)
+		}
+	}
+	permissions := splitClaim(raw.Organization.Permissions)
+	c.ActiveOrganizationPermissions = nil
+	for i, mask := range splitClaim(raw.Organization.FeaturePermissionMap) {
+		if i >= len(orgFeatures) {
+			break
+		}
+		bits, err := strconv.ParseUint(mask, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid feature permission map %s: %w", raw.Organization.FeaturePermissionMap, err)
+		}
+		for j, permission := range permissions {
+			if j < 64 && bits&(1<<j) != 0 {
+				c.ActiveOrganizationPermissions = append(c.ActiveOrganizationPermissions, "org:"+orgFeatures[i]+":"+permission)
+			}
+		}
+	}
+	return nil
+}
+
+// Splits a comma separated claim value.
+func splitClaim(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// Splits the scope from a feature or plan claim value, like "o:name".
+func splitScope(value string) (string, string) {
+	scope, name, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", value
+	}
+	return scope, name
 }
 
 // UnverifiedToken holds the result of a JWT decoding without any
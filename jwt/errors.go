@@ -0,0 +1,68 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+)
+
+// Errors that describe why a token failed verification. Verify
+// returns errors that can be matched against them with errors.Is.
+//
+//	_, err := jwt.Verify(ctx, params)
+//	if errors.Is(err, jwt.ErrTokenExpired) {
+//		// The session token needs to be refreshed.
+//	}
+var (
+	// ErrTokenMalformed means that the token cannot be parsed.
+	ErrTokenMalformed = errors.New("malformed token")
+	// ErrTokenExpired means that the token's 'exp' claim is in the
+	// past.
+	ErrTokenExpired = errors.New("token is expired")
+	// ErrTokenNotYetValid means that the token's 'nbf' or 'iat'
+	// claims are in the future.
+	ErrTokenNotYetValid = errors.New("token is not valid yet")
+	// ErrTokenSignatureInvalid means that the token's signature
+	// doesn't match the JSON Web Key.
+	ErrTokenSignatureInvalid = errors.New("invalid token signature")
+	// ErrKeyNotFound means that there's no JSON Web Key for the
+	// token's 'kid' header.
+	ErrKeyNotFound = jwks.ErrKeyNotFound
+	// ErrInvalidAlgorithm means that the token's signing algorithm
+	// is not accepted.
+	ErrInvalidAlgorithm = errors.New("invalid signing algorithm")
+	// ErrInvalidIssuer means that the token's 'iss' claim is not
+	// accepted.
+	ErrInvalidIssuer = errors.New("invalid issuer")
+	// ErrInvalidAudience means that the token's 'aud' claim doesn't
+	// contain an accepted audience.
+	ErrInvalidAudience = errors.New("invalid audience")
+	// ErrInvalidAuthorizedParty means that the token's 'azp' claim is
+	// not accepted.
+	ErrInvalidAuthorizedParty = errors.New("invalid authorized party")
+	// ErrInvalidActor means that the token belongs to an
+	// impersonated session and its 'act' claim is not accepted.
+	ErrInvalidActor = errors.New("invalid actor")
+)
+
+// Wraps errors from verifying the token signature.
+func signatureError(err error) error {
+	if errors.Is(err, jose.ErrCryptoFailure) {
+		return fmt.Errorf("%w: %w", ErrTokenSignatureInvalid, err)
+	}
+	return fmt.Errorf("%w: %w", ErrTokenMalformed, err)
+}
+
+// Wraps errors from validating the token's time claims.
+func timeError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrExpired):
+		return fmt.Errorf("%w: %w", ErrTokenExpired, err)
+	case errors.Is(err, jwt.ErrNotValidYet), errors.Is(err, jwt.ErrIssuedInTheFuture):
+		return fmt.Errorf("%w: %w", ErrTokenNotYetValid, err)
+	}
+	return err
+}
@@ -0,0 +1,103 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify_Errors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	kid := "kid"
+	now := time.Now()
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		claims map[string]any
+		params func(params *VerifyParams)
+		want   error
+	}{
+		{
+			name:   "malformed",
+			claims: map[string]any{"iss": "https://clerk.com"},
+			params: func(params *VerifyParams) {
+				params.Token = "not-a-token"
+			},
+			want: ErrTokenMalformed,
+		},
+		{
+			name:   "expired",
+			claims: map[string]any{"iss": "https://clerk.com", "exp": now.Add(-time.Hour).Unix()},
+			want:   ErrTokenExpired,
+		},
+		{
+			name:   "not yet valid",
+			claims: map[string]any{"iss": "https://clerk.com", "nbf": now.Add(time.Hour).Unix()},
+			want:   ErrTokenNotYetValid,
+		},
+		{
+			name:   "bad signature",
+			claims: map[string]any{"iss": "https://clerk.com"},
+			params: func(params *VerifyParams) {
+				params.JWK.Key = otherKey.Public()
+			},
+			want: ErrTokenSignatureInvalid,
+		},
+		{
+			name:   "bad algorithm",
+			claims: map[string]any{"iss": "https://clerk.com"},
+			params: func(params *VerifyParams) {
+				params.Algorithms = []string{string(jose.ES256)}
+			},
+			want: ErrInvalidAlgorithm,
+		},
+		{
+			name:   "bad issuer",
+			claims: map[string]any{"iss": "https://example.com"},
+			want:   ErrInvalidIssuer,
+		},
+		{
+			name:   "bad audience",
+			claims: map[string]any{"iss": "https://clerk.com", "aud": "other"},
+			params: func(params *VerifyParams) {
+				params.Audience = []string{"api"}
+			},
+			want: ErrInvalidAudience,
+		},
+		{
+			name:   "bad authorized party",
+			claims: map[string]any{"iss": "https://clerk.com", "azp": "https://evil.com"},
+			params: func(params *VerifyParams) {
+				params.AuthorizedPartyHandler = func(azp string) bool {
+					return azp == "https://example.com"
+				}
+			},
+			want: ErrInvalidAuthorizedParty,
+		},
+	} {
+		token, pubKey := clerktest.GenerateJWT(t, tc.claims, kid)
+		params := &VerifyParams{
+			Token: token,
+			JWK: &clerk.JSONWebKey{
+				Key:       pubKey,
+				KeyID:     kid,
+				Algorithm: string(jose.RS256),
+			},
+		}
+		if tc.params != nil {
+			tc.params(params)
+		}
+		_, err := Verify(ctx, params)
+		require.ErrorIs(t, err, tc.want, tc.name)
+	}
+}
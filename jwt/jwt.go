@@ -4,19 +4,50 @@ package jwt
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/internal/singleflight"
 	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/go-jose/go-jose/v3"
 	"github.com/go-jose/go-jose/v3/jwt"
 )
 
+// DefaultAlgorithms are the signing algorithms that are accepted
+// when no Algorithms are set in the VerifyParams.
+var DefaultAlgorithms = []string{
+	string(jose.RS256),
+	string(jose.RS384),
+	string(jose.RS512),
+	string(jose.PS256),
+	string(jose.PS384),
+	string(jose.PS512),
+	string(jose.ES256),
+	string(jose.ES384),
+	string(jose.ES512),
+	string(jose.EdDSA),
+}
+
 // AuthorizedPartyHandler is a type that can be used to perform checks
 // on the 'azp' claim.
 type AuthorizedPartyHandler func(string) bool
 
+// IssuerValidator is a type that can be used to perform checks on
+// the 'iss' claim. It should return false if the issuer is not
+// accepted.
+type IssuerValidator func(string) bool
+
+// ActorValidator is a type that can be used to perform checks on the
+// 'act' claim of impersonated sessions. It should return false if
+// the actor is not accepted.
+type ActorValidator func(*clerk.ActorClaims) bool
+
 // CustomClaimsConstructor can initialize structs for holding custom
 // JWT claims.
 type CustomClaimsConstructor func(context.Context) any
@@ -29,6 +60,8 @@ type VerifyParams struct {
 	// If the JWK parameter is provided, the Verify method won't
 	// fetch the JSON Web Key Set and there's no need to provide
 	// the JWKSClient parameter.
+	// Use clerk.JSONWebKeyFromSecret to verify tokens that are signed
+	// with a shared secret.
 	JWK *clerk.JSONWebKey
 	// JWKSClient is a jwks API client that will be used to fetch the
 	// JSON Web Key Set for verifying the Token with.
@@ -36,6 +69,11 @@ type VerifyParams struct {
 	// If no JWK or JWKSClient is provided, the Verify method will use
 	// a JWKSClient with the default Backend.
 	JWKSClient *jwks.Client
+	// JWKSCache is a cache for the JSON Web Key Set. If it's
+	// provided, the JSON Web Key for verifying the Token will be
+	// retrieved from the cache and the JWKSClient is not needed.
+	// The JWK parameter takes precedence.
+	JWKSCache *jwks.Cache
 	// Clock can be used to keep track of time and will replace usage of
 	// the [time] package. Pass a custom Clock to control the source of
 	// time or facilitate testing chronologically sensitive flows.
@@ -56,12 +94,41 @@ type VerifyParams struct {
 	// it's expired. Useful for defending against server clock skews.
 	Leeway time.Duration
 	// IsSatellite signifies that the JWT is verified on a satellite domain.
+	// Satellite domains validate the issuer only against the Issuers
+	// or with the IssuerValidator. If neither is provided, the issuer
+	// is not validated.
 	IsSatellite bool
 	// ProxyURL is the URL of the server that proxies the Clerk Frontend API.
 	ProxyURL *string
+	// PublishableKey is the Clerk publishable key for the instance.
+	// If it's provided, the token issuer must match the instance's
+	// Frontend API URL, as decoded from the publishable key.
+	PublishableKey *string
+	// Issuers is a list of accepted values for the 'iss' claim, like
+	// the Frontend API URLs of the instance's custom domains. The
+	// ProxyURL and the Frontend API URL of the PublishableKey are
+	// also accepted.
+	Issuers []string
+	// IssuerValidator can be used to perform custom validations on
+	// the 'iss' claim. It takes precedence over the Issuers, ProxyURL
+	// and PublishableKey.
+	IssuerValidator IssuerValidator
+	// Audience is a list of accepted values for the 'aud' claim. If
+	// it's provided, the token must have at least one of them in its
+	// audience.
+	Audience []string
 	// AuthorizedPartyHandler can be used to perform validations on the
 	// 'azp' claim.
 	AuthorizedPartyHandler AuthorizedPartyHandler
+	// ActorValidator can be used to perform validations on the 'act'
+	// claim. It's called only for tokens of impersonated sessions.
+	ActorValidator ActorValidator
+	// Algorithms is the list of signing algorithms that the Token
+	// can be signed with. Defaults to DefaultAlgorithms, or to the
+	// JWK algorithm for symmetric keys.
+	// Tokens with the 'none' algorithm are always rejected, and so are
+	// HMAC algorithms when the JWK is an asymmetric key.
+	Algorithms []string
 }
 
 // Verify verifies a Clerk session JWT and returns the parsed
@@ -69,12 +136,21 @@ type VerifyParams struct {
 func Verify(ctx context.Context, params *VerifyParams) (*clerk.SessionClaims, error) {
 	parsedToken, err := jwt.ParseSigned(params.Token)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrTokenMalformed, err)
 	}
 	if len(parsedToken.Headers) == 0 {
-		return nil, fmt.Errorf("missing JWT headers")
+		return nil, fmt.Errorf("%w: missing JWT headers", ErrTokenMalformed)
 	}
 	jwk := params.JWK
+	if jwk == nil && parsedToken.Headers[0].KeyID == "" {
+		return nil, fmt.Errorf("%w: missing jwt kid header claim", ErrTokenMalformed)
+	}
+	if jwk == nil && params.JWKSCache != nil {
+		jwk, err = params.JWKSCache.Get(ctx, parsedToken.Headers[0].KeyID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if jwk == nil {
 		jwk, err = GetJSONWebKey(ctx, &GetJSONWebKeyParams{
 			KeyID:      parsedToken.Headers[0].KeyID,
@@ -88,8 +164,9 @@ func Verify(ctx context.Context, params *VerifyParams) (*clerk.SessionClaims, er
 		return nil, fmt.Errorf("missing json web key, need to set JWK in the params")
 	}
 
-	if parsedToken.Headers[0].Algorithm != jwk.Algorithm {
-		return nil, fmt.Errorf("invalid signing algorithm %s", jwk.Algorithm)
+	err = validateAlgorithm(parsedToken.Headers[0].Algorithm, jwk, params.Algorithms)
+	if err != nil {
+		return nil, err
 	}
 
 	claims := &clerk.SessionClaims{}
@@ -100,7 +177,7 @@ func Verify(ctx context.Context, params *VerifyParams) (*clerk.SessionClaims, er
 	}
 	err = parsedToken.Claims(jwk.Key, allClaims...)
 	if err != nil {
-		return nil, err
+		return nil, signatureError(err)
 	}
 
 	clock := params.Clock
@@ -108,30 +185,182 @@ func Verify(ctx context.Context, params *VerifyParams) (*clerk.SessionClaims, er
 		clock = clerk.NewClock()
 	}
 	err = claims.ValidateWithLeeway(clock.Now().UTC(), params.Leeway)
+	if err != nil {
+		return nil, timeError(err)
+	}
+
+	err = validateIssuer(claims.Issuer, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// Non-satellite domains must validate the issuer.
-	if !params.IsSatellite && !isValidIssuer(claims.Issuer, params.ProxyURL) {
-		return nil, fmt.Errorf("invalid issuer %s", claims.Issuer)
+	if len(params.Audience) > 0 && !hasAudience(claims.Audience, params.Audience) {
+		return nil, fmt.Errorf("%w %v", ErrInvalidAudience, claims.Audience)
 	}
 
 	if params.AuthorizedPartyHandler != nil && !params.AuthorizedPartyHandler(claims.AuthorizedParty) {
-		return nil, fmt.Errorf("invalid authorized party %s", claims.AuthorizedParty)
+		return nil, fmt.Errorf("%w %s", ErrInvalidAuthorizedParty, claims.AuthorizedParty)
+	}
+
+	if claims.IsImpersonated() && params.ActorValidator != nil {
+		actor, err := claims.ActorClaims()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidActor, err)
+		}
+		if !params.ActorValidator(actor) {
+			return nil, fmt.Errorf("%w %s", ErrInvalidActor, actor.Subject)
+		}
 	}
 
 	return claims, nil
 }
 
-func isValidIssuer(iss string, proxyURL *string) bool {
-	if proxyURL != nil {
-		return iss == *proxyURL
+// VerifyWithClaims verifies a Clerk session JWT like Verify and
+// parses its custom claims into a value of type T. Any
+// CustomClaimsConstructor in the params is ignored.
+//
+//	type MyCustomClaims struct {
+//		Tier string `json:"tier"`
+//	}
+//	claims, custom, err := jwt.VerifyWithClaims[MyCustomClaims](ctx, params)
+func VerifyWithClaims[T any](ctx context.Context, params *VerifyParams) (*clerk.SessionClaims, *T, error) {
+	typedParams := *params
+	typedParams.CustomClaimsConstructor = func(_ context.Context) any {
+		return new(T)
+	}
+	claims, err := Verify(ctx, &typedParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	return claims, claims.Custom.(*T), nil
+}
+
+// Checks that the token's signing algorithm is allowed and that it
+// can be used with the JSON web key.
+// Symmetric keys must be pinned to an algorithm through the JWK.
+func validateAlgorithm(alg string, jwk *clerk.JSONWebKey, allowed []string) error {
+	if alg == "" || alg == "none" {
+		return fmt.Errorf("%w %q", ErrInvalidAlgorithm, alg)
+	}
+	symmetric := isSymmetricKey(jwk.Key)
+	if symmetric && jwk.Algorithm == "" {
+		return fmt.Errorf("%w: missing signing algorithm for symmetric json web key", ErrInvalidAlgorithm)
+	}
+	if allowed == nil {
+		allowed = DefaultAlgorithms
+		if symmetric {
+			allowed = []string{jwk.Algorithm}
+		}
+	}
+	isAllowed := false
+	for _, a := range allowed {
+		if a == alg {
+			isAllowed = true
+			break
+		}
+	}
+	if !isAllowed {
+		return fmt.Errorf("%w: %s is not allowed", ErrInvalidAlgorithm, alg)
 	}
+	if jwk.Algorithm != "" && jwk.Algorithm != alg {
+		return fmt.Errorf("%w %s, expected %s", ErrInvalidAlgorithm, alg, jwk.Algorithm)
+	}
+	if !keyMatchesAlgorithm(jwk.Key, alg) {
+		return fmt.Errorf("%w: %s cannot be used with a %T key", ErrInvalidAlgorithm, alg, jwk.Key)
+	}
+	return nil
+}
+
+// Reports whether the key is a shared secret for HMAC algorithms.
+func isSymmetricKey(key any) bool {
+	switch k := key.(type) {
+	case []byte:
+		return true
+	case jose.JSONWebKey:
+		return isSymmetricKey(k.Key)
+	case *jose.JSONWebKey:
+		return isSymmetricKey(k.Key)
+	}
+	return false
+}
+
+// Reports whether the key type is suitable for the signing
+// algorithm.
+func keyMatchesAlgorithm(key any, alg string) bool {
+	switch k := key.(type) {
+	case jose.JSONWebKey:
+		return keyMatchesAlgorithm(k.Key, alg)
+	case *jose.JSONWebKey:
+		return keyMatchesAlgorithm(k.Key, alg)
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		expected, err := clerk.JSONWebKeyAlgorithm(k)
+		return err == nil && alg == expected
+	case ed25519.PublicKey:
+		return alg == string(jose.EdDSA)
+	case []byte:
+		return strings.HasPrefix(alg, "HS")
+	}
+	return false
+}
+
+// Checks the 'iss' claim against the IssuerValidator, or the list
+// of accepted issuers. Without either, satellite domains skip the
+// check and other domains accept Clerk Frontend API URLs.
+func validateIssuer(iss string, params *VerifyParams) error {
+	if params.IssuerValidator != nil {
+		if !params.IssuerValidator(iss) {
+			return fmt.Errorf("%w %s", ErrInvalidIssuer, iss)
+		}
+		return nil
+	}
+
+	issuers := append([]string{}, params.Issuers...)
+	if !params.IsSatellite {
+		if params.ProxyURL != nil {
+			issuers = append(issuers, *params.ProxyURL)
+		}
+		if params.PublishableKey != nil {
+			pk, err := clerk.ParsePublishableKey(*params.PublishableKey)
+			if err != nil {
+				return err
+			}
+			issuers = append(issuers, pk.FrontendAPIURL())
+		}
+	}
+	if len(issuers) > 0 {
+		for _, issuer := range issuers {
+			if iss == issuer {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w %s", ErrInvalidIssuer, iss)
+	}
+
+	if params.IsSatellite || isClerkIssuer(iss) {
+		return nil
+	}
+	return fmt.Errorf("%w %s", ErrInvalidIssuer, iss)
+}
+
+func isClerkIssuer(iss string) bool {
 	return strings.HasPrefix(iss, "https://clerk.") ||
 		strings.Contains(iss, ".clerk.accounts")
 }
 
+// Reports whether any of the accepted values is in the audience.
+func hasAudience(audience, accepted []string) bool {
+	for _, aud := range audience {
+		for _, a := range accepted {
+			if aud == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type DecodeParams struct {
 	Token string
 }
@@ -178,7 +407,8 @@ type GetJSONWebKeyParams struct {
 // GetJSONWebKey fetches the JSON Web Key Set from the Clerk API
 // and returns the JSON Web Key corresponding to the provided KeyID.
 // A default client will be initialized if the provided JWKSClient
-// is nil.
+// is nil. The default client uses the Backend from the context, or
+// the package level Backend.
 func GetJSONWebKey(ctx context.Context, params *GetJSONWebKeyParams) (*clerk.JSONWebKey, error) {
 	if params.KeyID == "" {
 		return nil, fmt.Errorf("missing jwt kid header claim")
@@ -186,11 +416,18 @@ func GetJSONWebKey(ctx context.Context, params *GetJSONWebKeyParams) (*clerk.JSO
 
 	jwksClient := params.JWKSClient
 	if jwksClient == nil {
+		backend, ok := clerk.BackendFromContext(ctx)
+		if !ok {
+			backend = clerk.GetBackend()
+		}
 		jwksClient = &jwks.Client{
-			Backend: clerk.GetBackend(),
+			Backend: backend,
 		}
 	}
-	jwks, err := jwksClient.Get(ctx, &jwks.GetParams{})
+	// Concurrent requests for the same key set share a single fetch.
+	jwks, err := jwksFetches.Do(ctx, jwksFetchKey(jwksClient), func(ctx context.Context) (*clerk.JSONWebKeySet, error) {
+		return jwksClient.Get(ctx, &jwks.GetParams{})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -203,5 +440,19 @@ func GetJSONWebKey(ctx context.Context, params *GetJSONWebKeyParams) (*clerk.JSO
 			return k, nil
 		}
 	}
-	return nil, fmt.Errorf("missing json web key")
+	return nil, ErrKeyNotFound
+}
+
+// Collapses concurrent JSON Web Key Set requests in GetJSONWebKey.
+var jwksFetches singleflight.Group[any, *clerk.JSONWebKeySet]
+
+// Returns the key for JSON Web Key Set requests with the client.
+// Clients with the same Backend fetch the same key set. Backends are
+// compared by identity, so only pointers are used as keys; hashing
+// other values can panic.
+func jwksFetchKey(client *jwks.Client) any {
+	if client.Backend != nil && reflect.ValueOf(client.Backend).Kind() == reflect.Pointer {
+		return client.Backend
+	}
+	return client
 }
@@ -2,9 +2,20 @@ package jwt
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -261,6 +272,41 @@ func TestVerify_CustomClaims(t *testing.T) {
 // TestVerify_UsesTheJWKSClient tests that when verifying a JWT if
 // you don't provide the JWK, the Verify method will make a request
 // to GET /jwks to fetch the JWK set.
+func TestVerifyWithClaims(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	kid := "kid"
+	tokenClaims := map[string]any{
+		"domain":      "clerk.com",
+		"environment": "production",
+		"sub":         "user_123",
+		"iss":         "https://clerk.com",
+	}
+	token, pubKey := clerktest.GenerateJWT(t, tokenClaims, kid)
+	params := &VerifyParams{
+		Token: token,
+		JWK: &clerk.JSONWebKey{
+			Key:       pubKey,
+			KeyID:     kid,
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		},
+	}
+	claims, customClaims, err := VerifyWithClaims[testCustomClaims](ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, "user_123", claims.Subject)
+	require.Equal(t, "clerk.com", customClaims.Domain)
+	require.Equal(t, "production", customClaims.Environment)
+	require.Same(t, customClaims, claims.Custom)
+	// The params are not modified.
+	require.Nil(t, params.CustomClaimsConstructor)
+
+	params.Token = "invalid"
+	_, customClaims, err = VerifyWithClaims[testCustomClaims](ctx, params)
+	require.Error(t, err)
+	require.Nil(t, customClaims)
+}
+
 func TestVerify_UsesTheJWKSClient(t *testing.T) {
 	t.Parallel()
 	kid := "kid"
@@ -460,3 +506,417 @@ func TestGetJSONWebKey_UsesTheJWKSClient(t *testing.T) {
 	// A request was made to fetch the JWKS
 	require.Equal(t, 1, totalJWKSRequests)
 }
+
+func TestVerify_PublishableKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	kid := "kid"
+	token, pubKey := clerktest.GenerateJWT(t, map[string]any{"iss": "https://clerk.example.com"}, kid)
+	jwk := &clerk.JSONWebKey{
+		Key:       pubKey,
+		KeyID:     kid,
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}
+
+	// The issuer matches the Frontend API encoded in the publishable key.
+	_, err := Verify(ctx, &VerifyParams{
+		Token:          token,
+		JWK:            jwk,
+		PublishableKey: clerk.String("pk_live_Y2xlcmsuZXhhbXBsZS5jb20k"),
+	})
+	require.NoError(t, err)
+
+	// The issuer belongs to a different instance.
+	_, err = Verify(ctx, &VerifyParams{
+		Token:          token,
+		JWK:            jwk,
+		PublishableKey: clerk.String("pk_test_aGFwcHktaGlwcG8tMS5jbGVyay5hY2NvdW50cy5kZXYk"),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "issuer")
+
+	// Invalid publishable key.
+	_, err = Verify(ctx, &VerifyParams{
+		Token:          token,
+		JWK:            jwk,
+		PublishableKey: clerk.String("pk_test_invalid"),
+	})
+	require.Error(t, err)
+}
+
+func TestVerify_Algorithms(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	claims := map[string]any{"iss": "https://clerk.com", "sub": "user_123"}
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		alg    jose.SignatureAlgorithm
+		key    any
+		pubKey any
+	}{
+		{alg: jose.ES256, key: p256Key, pubKey: p256Key.Public()},
+		{alg: jose.ES384, key: p384Key, pubKey: p384Key.Public()},
+		{alg: jose.EdDSA, key: edKey, pubKey: edKey.Public()},
+	} {
+		token := clerktest.GenerateJWTWithKey(t, claims, "kid", tc.alg, tc.key)
+		// The algorithm is inferred from the PEM encoded key.
+		der, err := x509.MarshalPKIXPublicKey(tc.pubKey)
+		require.NoError(t, err)
+		jwk, err := clerk.JSONWebKeyFromPEM(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+		require.NoError(t, err)
+		require.Equal(t, string(tc.alg), jwk.Algorithm)
+
+		verified, err := Verify(ctx, &VerifyParams{Token: token, JWK: jwk})
+		require.NoError(t, err, tc.alg)
+		require.Equal(t, "user_123", verified.Subject)
+
+		// The algorithm is not in the allowlist.
+		_, err = Verify(ctx, &VerifyParams{
+			Token:      token,
+			JWK:        jwk,
+			Algorithms: []string{string(jose.RS256)},
+		})
+		require.Error(t, err, tc.alg)
+		require.Contains(t, err.Error(), "not allowed")
+	}
+
+	// The algorithm doesn't match the key type.
+	token := clerktest.GenerateJWTWithKey(t, claims, "kid", jose.ES256, p256Key)
+	_, err = Verify(ctx, &VerifyParams{
+		Token: token,
+		JWK:   &clerk.JSONWebKey{Key: edKey.Public()},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot be used")
+
+	// HMAC algorithms are rejected for asymmetric keys, even if
+	// they are allowed.
+	token = clerktest.GenerateJWTWithKey(t, claims, "kid", jose.HS256, []byte("a-shared-secret-that-is-long-enough"))
+	_, err = Verify(ctx, &VerifyParams{
+		Token:      token,
+		JWK:        &clerk.JSONWebKey{Key: p256Key.Public()},
+		Algorithms: []string{string(jose.HS256)},
+	})
+	require.Error(t, err)
+
+	// Unsigned tokens are always rejected.
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"kid"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"https://clerk.com"}`))
+	_, err = Verify(ctx, &VerifyParams{
+		Token:      header + "." + payload + ".",
+		JWK:        &clerk.JSONWebKey{Key: p256Key.Public()},
+		Algorithms: []string{"none"},
+	})
+	require.Error(t, err)
+}
+
+func TestVerify_SymmetricKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	claims := map[string]any{"iss": "https://clerk.com", "sub": "user_123"}
+	secret := []byte("a-shared-secret-that-is-long-enough")
+
+	jwk, err := clerk.JSONWebKeyFromSecret(secret, string(jose.HS256))
+	require.NoError(t, err)
+	token := clerktest.GenerateJWTWithKey(t, claims, "", jose.HS256, secret)
+	verified, err := Verify(ctx, &VerifyParams{Token: token, JWK: jwk})
+	require.NoError(t, err)
+	require.Equal(t, "user_123", verified.Subject)
+
+	// The algorithm is pinned by the JWK.
+	token = clerktest.GenerateJWTWithKey(t, claims, "", jose.HS384, secret)
+	_, err = Verify(ctx, &VerifyParams{Token: token, JWK: jwk})
+	require.Error(t, err)
+
+	// Symmetric keys without an algorithm are rejected.
+	_, err = Verify(ctx, &VerifyParams{
+		Token: token,
+		JWK:   &clerk.JSONWebKey{Key: secret},
+	})
+	require.Error(t, err)
+
+	// Symmetric keys cannot verify tokens signed with asymmetric keys.
+	rsaToken, _ := clerktest.GenerateJWT(t, claims, "kid")
+	_, err = Verify(ctx, &VerifyParams{
+		Token:      rsaToken,
+		JWK:        jwk,
+		Algorithms: []string{string(jose.HS256), string(jose.RS256)},
+	})
+	require.Error(t, err)
+
+	_, err = clerk.JSONWebKeyFromSecret(secret, string(jose.RS256))
+	require.Error(t, err)
+}
+
+func TestVerify_UsesTheJWKSCache(t *testing.T) {
+	t.Parallel()
+	kid := "kid"
+	tokenClaims := map[string]any{
+		"sub": "user_123",
+		"iss": "https://clerk.com",
+	}
+	token, pubKey := clerktest.GenerateJWT(t, tokenClaims, kid)
+	rawJWK, err := json.Marshal(jose.JSONWebKey{Key: pubKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"})
+	require.NoError(t, err)
+
+	totalJWKSRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/jwks" && r.Method == http.MethodGet {
+			totalJWKSRequests++
+			_, err := w.Write([]byte(fmt.Sprintf(`{"keys":[%s]}`, rawJWK)))
+			require.NoError(t, err)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = ts.Client()
+	config.URL = &ts.URL
+	cache := jwks.NewCache(&jwks.CacheConfig{
+		Client: jwks.NewClient(config),
+	})
+
+	for i := 0; i < 3; i++ {
+		claims, err := Verify(context.Background(), &VerifyParams{
+			Token:     token,
+			JWKSCache: cache,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "user_123", claims.Subject)
+	}
+	// The JWKS was fetched only once.
+	require.Equal(t, 1, totalJWKSRequests)
+}
+
+func TestGetJSONWebKey_ConcurrentFetches(t *testing.T) {
+	t.Parallel()
+	kid := "kid"
+	var totalJWKSRequests atomic.Int64
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		totalJWKSRequests.Add(1)
+		<-release
+		_, err := w.Write([]byte(fmt.Sprintf(`{"keys":[{"use":"sig","kty":"RSA","kid":"%s","alg":"RS256","n":"ypsS9Iq26F71B3lPjT_IMtglDXo8Dko9h5UBmrvkWo6pdH_4zmMjeghozaHY1aQf1dHUBLsov_XvG_t-1yf7tFfO_ImC1JqSQwdSjrXZp3oMNFHwdwAknvtlBg3sBxJ8nM1WaCWaTlb2JhEmczIji15UG6V0M2cAp2VK_brcylQROaJLC2zVa4usGi4AHzAHaRUTv6XB9bGYMvkM-ZniuXgp9dPurisIIWg25DGrTaH-kg8LPaqGwa54eLEnvfAe0ZH_MvA4_bn_u_iDkQ9ZI_CD1vwf0EDnzLgd9ZG1khGsqmXY_4WiLRGsPqZe90HzaBJma9sAxXB4qj_aNnwD5w","e":"AQAB"}]}`, kid)))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = ts.Client()
+	config.URL = &ts.URL
+	backend := clerk.NewBackend(&config.BackendConfig)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Clients with the same Backend share the request.
+			jwk, err := GetJSONWebKey(context.Background(), &GetJSONWebKeyParams{
+				KeyID:      kid,
+				JWKSClient: &jwks.Client{Backend: backend},
+			})
+			require.NoError(t, err)
+			require.Equal(t, kid, jwk.KeyID)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int64(1), totalJWKSRequests.Load())
+}
+
+// A Backend that can't be used as a map key, because one of its
+// fields holds a slice.
+type valueBackend struct {
+	clerk.Backend
+	extra any
+}
+
+func TestJWKSFetchKey(t *testing.T) {
+	t.Parallel()
+	backend := clerk.NewBackend(&clerk.BackendConfig{})
+	// Clients with the same pointer Backend share the key.
+	require.Equal(t, backend, jwksFetchKey(&jwks.Client{Backend: backend}))
+
+	// Other clients use their own key.
+	client := &jwks.Client{Backend: valueBackend{Backend: backend, extra: []string{"value"}}}
+	key := jwksFetchKey(client)
+	require.True(t, key == any(client))
+	require.NotPanics(t, func() {
+		keys := map[any]struct{}{}
+		keys[key] = struct{}{}
+	})
+}
+
+func TestVerify_Issuers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	kid := "kid"
+	token, pubKey := clerktest.GenerateJWT(t, map[string]any{"iss": "https://clerk.custom-domain.com"}, kid)
+	jwk := &clerk.JSONWebKey{Key: pubKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"}
+
+	// The issuer is one of the accepted issuers.
+	_, err := Verify(ctx, &VerifyParams{
+		Token:   token,
+		JWK:     jwk,
+		Issuers: []string{"https://clerk.example.com", "https://clerk.custom-domain.com"},
+	})
+	require.NoError(t, err)
+
+	// The issuer is not accepted, even though it's a Clerk
+	// Frontend API URL.
+	_, err = Verify(ctx, &VerifyParams{
+		Token:   token,
+		JWK:     jwk,
+		Issuers: []string{"https://clerk.example.com"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "issuer")
+
+	// Satellite domains validate the issuer against the accepted
+	// issuers.
+	_, err = Verify(ctx, &VerifyParams{
+		Token:       token,
+		JWK:         jwk,
+		IsSatellite: true,
+		Issuers:     []string{"https://clerk.example.com"},
+	})
+	require.Error(t, err)
+	_, err = Verify(ctx, &VerifyParams{
+		Token:       token,
+		JWK:         jwk,
+		IsSatellite: true,
+		Issuers:     []string{"https://clerk.custom-domain.com"},
+	})
+	require.NoError(t, err)
+
+	// The issuer validator takes precedence.
+	_, err = Verify(ctx, &VerifyParams{
+		Token:   token,
+		JWK:     jwk,
+		Issuers: []string{"https://clerk.custom-domain.com"},
+		IssuerValidator: func(iss string) bool {
+			return iss == "https://clerk.example.com"
+		},
+	})
+	require.Error(t, err)
+	_, err = Verify(ctx, &VerifyParams{
+		Token:       token,
+		JWK:         jwk,
+		IsSatellite: true,
+		IssuerValidator: func(iss string) bool {
+			return strings.HasSuffix(iss, ".custom-domain.com")
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestVerify_Audience(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	kid := "kid"
+	token, pubKey := clerktest.GenerateJWT(t, map[string]any{
+		"iss": "https://clerk.com",
+		"aud": []string{"api.example.com", "admin.example.com"},
+	}, kid)
+	jwk := &clerk.JSONWebKey{Key: pubKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"}
+
+	claims, err := Verify(ctx, &VerifyParams{
+		Token:    token,
+		JWK:      jwk,
+		Audience: []string{"admin.example.com"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"api.example.com", "admin.example.com"}, claims.Audience)
+
+	_, err = Verify(ctx, &VerifyParams{
+		Token:    token,
+		JWK:      jwk,
+		Audience: []string{"other.example.com"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "audience")
+
+	// Tokens without an audience are rejected when an audience is
+	// expected.
+	token, pubKey = clerktest.GenerateJWT(t, map[string]any{"iss": "https://clerk.com"}, kid)
+	_, err = Verify(ctx, &VerifyParams{
+		Token:    token,
+		JWK:      &clerk.JSONWebKey{Key: pubKey, KeyID: kid, Algorithm: string(jose.RS256)},
+		Audience: []string{"api.example.com"},
+	})
+	require.Error(t, err)
+}
+
+func TestVerify_Actor(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	kid := "kid"
+	token, pubKey := clerktest.GenerateJWT(t, map[string]any{
+		"iss": "https://clerk.com",
+		"sub": "user_123",
+		"act": map[string]any{
+			"sub": "user_456",
+			"iss": "https://dashboard.clerk.com",
+			"act": map[string]any{"sub": "user_789"},
+		},
+	}, kid)
+	jwk := &clerk.JSONWebKey{Key: pubKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"}
+
+	claims, err := Verify(ctx, &VerifyParams{Token: token, JWK: jwk})
+	require.NoError(t, err)
+	require.True(t, claims.IsImpersonated())
+	actor, err := claims.ActorClaims()
+	require.NoError(t, err)
+	require.Equal(t, "user_456", actor.Subject)
+	require.Equal(t, "https://dashboard.clerk.com", actor.Issuer)
+	require.Equal(t, []string{"user_456", "user_789"}, actor.Subjects())
+
+	_, err = Verify(ctx, &VerifyParams{
+		Token: token,
+		JWK:   jwk,
+		ActorValidator: func(actor *clerk.ActorClaims) bool {
+			return actor.Subject == "user_000"
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidActor)
+
+	// The validator is not called for sessions that aren't
+	// impersonated.
+	token, pubKey = clerktest.GenerateJWT(t, map[string]any{"iss": "https://clerk.com"}, kid)
+	claims, err = Verify(ctx, &VerifyParams{
+		Token: token,
+		JWK:   &clerk.JSONWebKey{Key: pubKey, KeyID: kid, Algorithm: string(jose.RS256)},
+		ActorValidator: func(_ *clerk.ActorClaims) bool {
+			return false
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, claims.IsImpersonated())
+
+	// Actors that are not objects are accepted, unless they need to
+	// be validated.
+	token, pubKey = clerktest.GenerateJWT(t, map[string]any{"iss": "https://clerk.com", "act": "user_456"}, kid)
+	jwk = &clerk.JSONWebKey{Key: pubKey, KeyID: kid, Algorithm: string(jose.RS256)}
+	claims, err = Verify(ctx, &VerifyParams{Token: token, JWK: jwk})
+	require.NoError(t, err)
+	require.True(t, claims.IsImpersonated())
+	_, err = Verify(ctx, &VerifyParams{
+		Token: token,
+		JWK:   jwk,
+		ActorValidator: func(_ *clerk.ActorClaims) bool {
+			return true
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidActor)
+}
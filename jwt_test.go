@@ -1,6 +1,8 @@
 package clerk
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,3 +60,108 @@ func TestSessionClaimsHasPermission(t *testing.T) {
 		require.Equal(t, claims.HasPermission(tc.permission), tc.want)
 	}
 }
+
+func TestSessionClaimsFromContextAs(t *testing.T) {
+	t.Parallel()
+	type customClaims struct {
+		Tier string
+	}
+	type otherClaims struct{}
+
+	ctx := context.Background()
+	_, _, ok := SessionClaimsFromContextAs[customClaims](ctx)
+	require.False(t, ok)
+
+	claims := &SessionClaims{Custom: &customClaims{Tier: "gold"}}
+	ctx = ContextWithSessionClaims(ctx, claims)
+	sessionClaims, custom, ok := SessionClaimsFromContextAs[customClaims](ctx)
+	require.True(t, ok)
+	require.Same(t, claims, sessionClaims)
+	require.Equal(t, "gold", custom.Tier)
+
+	// The custom claims have a different type.
+	sessionClaims, _, ok = SessionClaimsFromContextAs[otherClaims](ctx)
+	require.False(t, ok)
+	require.Same(t, claims, sessionClaims)
+}
+
+func TestSessionClaims_Versions(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{
+			name: "version 1",
+			data: `{
+				"sub": "user_123",
+				"org_id": "org_123",
+				"org_slug": "acme",
+				"org_role": "org:admin",
+				"org_permissions": ["org:reports:read", "org:reports:manage", "org:invoices:read"]
+			}`,
+		},
+		{
+			name: "version 2",
+			data: `{
+				"v": 2,
+				"sub": "user_123",
+				"fea": "o:reports,u:export,o:invoices",
+				"o": {"id": "org_123", "slg": "acme", "rol": "admin", "per": "read,manage", "fpm": "3,1"}
+			}`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			claims := &SessionClaims{}
+			err := json.Unmarshal([]byte(tc.data), claims)
+			require.NoError(t, err)
+			require.Equal(t, "user_123", claims.Subject)
+			require.Equal(t, "org_123", claims.ActiveOrganizationID)
+			require.Equal(t, "acme", claims.ActiveOrganizationSlug)
+			require.True(t, claims.HasRole("org:admin"))
+			require.Equal(t, []string{"org:reports:read", "org:reports:manage", "org:invoices:read"}, claims.ActiveOrganizationPermissions)
+			require.True(t, claims.HasPermission("org:reports:manage"))
+			require.False(t, claims.HasPermission("org:invoices:manage"))
+		})
+	}
+}
+
+func TestSessionClaims_FactorVerificationAge(t *testing.T) {
+	t.Parallel()
+	claims := &SessionClaims{}
+	err := json.Unmarshal([]byte(`{"v":2,"fva":[5,-1]}`), claims)
+	require.NoError(t, err)
+	require.Equal(t, 2, claims.Version)
+	require.Equal(t, []int{5, -1}, claims.FactorVerificationAge)
+}
+
+func TestSessionClaimsHasFeatureAndPlan(t *testing.T) {
+	t.Parallel()
+	claims := &SessionClaims{}
+	err := json.Unmarshal([]byte(`{"v":2,"fea":"u:export,o:analytics,ou:support","pla":"u:free,o:pro"}`), claims)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u:export", "o:analytics", "ou:support"}, claims.Features)
+
+	for _, tc := range []struct {
+		feature string
+		want    bool
+	}{
+		{feature: "export", want: true},
+		{feature: "user:export", want: true},
+		{feature: "org:export", want: false},
+		{feature: "analytics", want: true},
+		{feature: "org:analytics", want: true},
+		{feature: "user:analytics", want: false},
+		{feature: "org:support", want: true},
+		{feature: "user:support", want: true},
+		{feature: "billing", want: false},
+	} {
+		require.Equal(t, tc.want, claims.HasFeature(tc.feature), tc.feature)
+	}
+	require.True(t, claims.HasPlan("free"))
+	require.True(t, claims.HasPlan("org:pro"))
+	require.False(t, claims.HasPlan("user:pro"))
+	require.False(t, claims.HasPlan("enterprise"))
+}
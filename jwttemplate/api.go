@@ -9,32 +9,48 @@ import (
 )
 
 // Create creates a new JWT template.
-func Create(ctx context.Context, params *CreateParams) (*clerk.JWTTemplate, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.JWTTemplate, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Get returns details about a JWT template.
-func Get(ctx context.Context, id string) (*clerk.JWTTemplate, error) {
-	return getClient().Get(ctx, id)
+func Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.JWTTemplate, error) {
+	return getClient(ctx).Get(ctx, id, opts...)
 }
 
 // Update updates the JWT template specified by id.
-func Update(ctx context.Context, id string, params *UpdateParams) (*clerk.JWTTemplate, error) {
-	return getClient().Update(ctx, id, params)
+func Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.JWTTemplate, error) {
+	return getClient(ctx).Update(ctx, id, params, opts...)
 }
 
 // Delete deletes a JWT template.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
 // List returns a list of JWT templates.
-func List(ctx context.Context, params *ListParams) (*clerk.JWTTemplateList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.JWTTemplateList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+// VerifyToken verifies a token that was generated from a JWT template
+// and returns its claims.
+// The template's allowed clock skew is used as the leeway, unless a
+// Leeway is provided, and the token cannot be valid for longer than
+// the template's lifetime.
+// Templates with a custom signing key require a JWK and tokens must
+// be signed with the template's signing algorithm. Use
+// clerk.JSONWebKeyFromSecret for HMAC signing keys.
+func VerifyToken(ctx context.Context, params *VerifyTokenParams, opts ...clerk.RequestOption) (*clerk.SessionClaims, error) {
+	return getClient(ctx).VerifyToken(ctx, params, opts...)
+}
+
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
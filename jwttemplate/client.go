@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
 )
 
 //go:generate go run ../cmd/gen/main.go
@@ -37,8 +40,8 @@ type CreateParams struct {
 }
 
 // Create creates a new JWT template.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.JWTTemplate, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.JWTTemplate, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	template := &clerk.JWTTemplate{}
 	err := c.Backend.Call(ctx, req, template)
@@ -46,12 +49,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.JWTTe
 }
 
 // Get returns details about a JWT template.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.JWTTemplate, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.JWTTemplate, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	template := &clerk.JWTTemplate{}
 	err = c.Backend.Call(ctx, req, template)
 	return template, err
@@ -69,12 +72,12 @@ type UpdateParams struct {
 }
 
 // Update updates the JWT template specified by id.
-func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*clerk.JWTTemplate, error) {
+func (c *Client) Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.JWTTemplate, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	template := &clerk.JWTTemplate{}
 	err = c.Backend.Call(ctx, req, template)
@@ -82,12 +85,12 @@ func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*
 }
 
 // Delete deletes a JWT template.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	template := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, template)
 	return template, err
@@ -98,10 +101,74 @@ type ListParams struct {
 }
 
 // List returns a list of JWT templates.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.JWTTemplateList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path))
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.JWTTemplateList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path), opts...)
 	req.SetParams(params)
 	list := &clerk.JWTTemplateList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
 }
+
+type VerifyTokenParams struct {
+	jwt.VerifyParams
+	// TemplateID is the ID of the JWT template that the token was
+	// generated from. The template is fetched from the API, unless
+	// the Template is provided.
+	TemplateID string
+	// Template is the JWT template that the token was generated
+	// from.
+	Template *clerk.JWTTemplate
+}
+
+// VerifyToken verifies a token that was generated from a JWT template
+// and returns its claims.
+// The template's allowed clock skew is used as the leeway, unless a
+// Leeway is provided, and the token cannot be valid for longer than
+// the template's lifetime.
+// Templates with a custom signing key require a JWK and tokens must
+// be signed with the template's signing algorithm. Use
+// clerk.JSONWebKeyFromSecret for HMAC signing keys.
+func (c *Client) VerifyToken(ctx context.Context, params *VerifyTokenParams, opts ...clerk.RequestOption) (*clerk.SessionClaims, error) {
+	template := params.Template
+	if template == nil {
+		if params.TemplateID == "" {
+			return nil, fmt.Errorf("missing JWT template")
+		}
+		var err error
+		template, err = c.Get(ctx, params.TemplateID, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	verifyParams := params.VerifyParams
+	if verifyParams.Leeway == 0 {
+		verifyParams.Leeway = time.Duration(template.AllowedClockSkew) * time.Second
+	}
+	if template.CustomSigningKey {
+		if verifyParams.JWK == nil {
+			return nil, fmt.Errorf("JWT template %s uses a custom signing key, a JWK is required", template.Name)
+		}
+		// Pin the algorithm to the one configured for the template.
+		verifyParams.Algorithms = []string{template.SigningAlgorithm}
+		if verifyParams.JWK.Algorithm == "" {
+			jwk := *verifyParams.JWK
+			jwk.Algorithm = template.SigningAlgorithm
+			verifyParams.JWK = &jwk
+		}
+	} else if verifyParams.JWK == nil && verifyParams.JWKSClient == nil {
+		verifyParams.JWKSClient = &jwks.Client{Backend: c.Backend}
+	}
+
+	claims, err := jwt.Verify(ctx, &verifyParams)
+	if err != nil {
+		return nil, err
+	}
+	if claims.IssuedAt == nil || claims.Expiry == nil {
+		return nil, fmt.Errorf("missing iat or exp claim")
+	}
+	if lifetime := *claims.Expiry - *claims.IssuedAt; lifetime > template.Lifetime {
+		return nil, fmt.Errorf("token lifetime %ds exceeds the JWT template lifetime %ds", lifetime, template.Lifetime)
+	}
+	return claims, nil
+}
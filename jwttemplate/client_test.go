@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/clerk/clerk-sdk-go/v2/clerktest"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
+	"github.com/go-jose/go-jose/v3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -173,3 +176,103 @@ func TestJWTTemplateClientList(t *testing.T) {
 	require.Equal(t, "jtmpl_123", list.JWTTemplates[0].ID)
 	require.Equal(t, "the-name", list.JWTTemplates[0].Name)
 }
+
+func TestJWTTemplateClientVerifyToken(t *testing.T) {
+	t.Parallel()
+	id := "jtmpl_123"
+	secret := []byte("a-shared-secret-that-is-long-enough")
+	now := time.Now()
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = &http.Client{
+		Transport: &clerktest.RoundTripper{
+			T:      t,
+			Out:    json.RawMessage(fmt.Sprintf(`{"id":"%s","name":"legacy","lifetime":60,"allowed_clock_skew":5,"custom_signing_key":true,"signing_algorithm":"HS256"}`, id)),
+			Method: http.MethodGet,
+			Path:   "/v1/jwt_templates/" + id,
+		},
+	}
+	client := NewClient(config)
+
+	token := clerktest.GenerateJWTWithKey(t, map[string]any{
+		"iss": "https://clerk.com",
+		"sub": "user_123",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Minute).Unix(),
+	}, "", jose.HS256, secret)
+	// The JWK algorithm is pinned by the template.
+	claims, err := client.VerifyToken(context.Background(), &VerifyTokenParams{
+		VerifyParams: jwt.VerifyParams{
+			Token: token,
+			JWK:   &clerk.JSONWebKey{Key: secret},
+		},
+		TemplateID: id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "user_123", claims.Subject)
+}
+
+func TestJWTTemplateClientVerifyToken_Template(t *testing.T) {
+	t.Parallel()
+	secret := []byte("a-shared-secret-that-is-long-enough")
+	now := time.Now()
+	template := &clerk.JWTTemplate{
+		Name:             "legacy",
+		Lifetime:         60,
+		AllowedClockSkew: 5,
+		CustomSigningKey: true,
+		SigningAlgorithm: string(jose.HS256),
+	}
+	jwk, err := clerk.JSONWebKeyFromSecret(secret, string(jose.HS256))
+	require.NoError(t, err)
+	// No API requests are made when the template is provided.
+	client := NewClient(&clerk.ClientConfig{})
+	ctx := context.Background()
+
+	// The token is valid for longer than the template lifetime.
+	token := clerktest.GenerateJWTWithKey(t, map[string]any{
+		"iss": "https://clerk.com",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}, "", jose.HS256, secret)
+	_, err = client.VerifyToken(ctx, &VerifyTokenParams{
+		VerifyParams: jwt.VerifyParams{Token: token, JWK: jwk},
+		Template:     template,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "lifetime")
+
+	// The template's allowed clock skew is used as leeway.
+	token = clerktest.GenerateJWTWithKey(t, map[string]any{
+		"iss": "https://clerk.com",
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(-3 * time.Second).Unix(),
+	}, "", jose.HS256, secret)
+	_, err = client.VerifyToken(ctx, &VerifyTokenParams{
+		VerifyParams: jwt.VerifyParams{Token: token, JWK: jwk},
+		Template:     template,
+	})
+	require.NoError(t, err)
+
+	// The token is signed with a different algorithm than the
+	// template's.
+	token = clerktest.GenerateJWTWithKey(t, map[string]any{
+		"iss": "https://clerk.com",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Minute).Unix(),
+	}, "", jose.HS512, secret)
+	_, err = client.VerifyToken(ctx, &VerifyTokenParams{
+		VerifyParams: jwt.VerifyParams{
+			Token: token,
+			JWK:   &clerk.JSONWebKey{Key: secret},
+		},
+		Template: template,
+	})
+	require.Error(t, err)
+
+	// Templates with custom signing keys require a JWK.
+	_, err = client.VerifyToken(ctx, &VerifyTokenParams{
+		VerifyParams: jwt.VerifyParams{Token: token},
+		Template:     template,
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,160 @@
+package clerk
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// The value that replaces sensitive information in log records.
+const redacted = "[REDACTED]"
+
+// Body fields that hold sensitive information and should never
+// be logged.
+var sensitiveFields = map[string]struct{}{
+	"password":        {},
+	"password_digest": {},
+	"totp_secret":     {},
+	"backup_codes":    {},
+	"secret":          {},
+	"secret_key":      {},
+	"private_key":     {},
+	"client_secret":   {},
+	"signing_key":     {},
+	"token":           {},
+}
+
+// Regular expression that matches Clerk secret keys.
+var secretKeyRE = regexp.MustCompile(`sk_(test|live)_[A-Za-z0-9]+`)
+
+// Logs a single attempt of a request to the Clerk API.
+func (b *defaultBackend) logAttempt(req *http.Request, resp *APIResponse, err error, attempt int, duration time.Duration) {
+	if b.Logger == nil {
+		return
+	}
+	ctx := req.Context()
+	level := slog.LevelInfo
+	if err != nil || !resp.Success() {
+		level = slog.LevelWarn
+	}
+	if !b.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redactString(err.Error())))
+	}
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.String("trace_id", resp.TraceID),
+		)
+		if resp.IdempotencyKey != "" {
+			attrs = append(attrs, slog.String("idempotency_key", resp.IdempotencyKey))
+		}
+		if codes := errorCodes(resp); len(codes) > 0 {
+			attrs = append(attrs, slog.Any("error_codes", codes))
+		}
+	}
+	if b.Logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request_headers", redactHeader(req.Header)))
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				raw, err := io.ReadAll(body)
+				if err == nil {
+					attrs = append(attrs, slog.String("request_body", redactBody(raw)))
+				}
+			}
+		}
+		if resp != nil {
+			attrs = append(attrs, slog.String("response_body", redactBody(resp.RawJSON)))
+		}
+	}
+
+	b.Logger.LogAttrs(ctx, level, "clerk API request", attrs...)
+}
+
+// Returns the error codes included in an error API response.
+func errorCodes(resp *APIResponse) []string {
+	if resp.Success() || len(resp.RawJSON) == 0 {
+		return nil
+	}
+	apiErr := &APIErrorResponse{}
+	if err := json.Unmarshal(resp.RawJSON, apiErr); err != nil {
+		return nil
+	}
+	codes := make([]string, 0, len(apiErr.Errors))
+	for _, e := range apiErr.Errors {
+		codes = append(codes, e.Code)
+	}
+	return codes
+}
+
+// Returns a copy of the header with the Authorization value and
+// any secret keys redacted.
+func redactHeader(header http.Header) http.Header {
+	res := make(http.Header, len(header))
+	for k, values := range header {
+		if strings.EqualFold(k, "Authorization") {
+			res[k] = []string{redacted}
+			continue
+		}
+		res[k] = make([]string, len(values))
+		for i, v := range values {
+			res[k][i] = redactString(v)
+		}
+	}
+	return res
+}
+
+// Replaces the values of sensitive fields in a JSON body. Bodies
+// that are not JSON are omitted.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var data any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return redacted
+	}
+	redactValue(data)
+	res, err := json.Marshal(data)
+	if err != nil {
+		return redacted
+	}
+	return redactString(string(res))
+}
+
+// Walks the JSON value recursively and replaces the values for
+// sensitive fields in place.
+func redactValue(v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, field := range val {
+			if _, ok := sensitiveFields[strings.ToLower(k)]; ok {
+				val[k] = redacted
+				continue
+			}
+			redactValue(field)
+		}
+	case []any:
+		for _, item := range val {
+			redactValue(item)
+		}
+	}
+}
+
+// Replaces any secret keys in the provided string.
+func redactString(s string) string {
+	return secretKeyRE.ReplaceAllString(s, redacted)
+}
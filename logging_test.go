@@ -0,0 +1,126 @@
+package clerk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCall_Logger(t *testing.T) {
+	t.Parallel()
+	var totalRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Clerk-Trace-Id", "trace-id")
+		if atomic.AddInt32(&totalRequests, 1) < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`{"errors":[{"code":"too_many_requests"}]}`))
+			require.NoError(t, err)
+			return
+		}
+		_, err := w.Write([]byte(`{"id":"res_123","backup_codes":["123456"]}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	secretKey := "sk_test_secretkey123"
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Key:        &secretKey,
+		Logger:     logger,
+		Retry: &RetryConfig{
+			InitialBackoff:     time.Millisecond,
+			RetryNonIdempotent: true,
+		},
+	})
+	req := NewAPIRequest(http.MethodPost, "/users")
+	req.SetParams(&struct {
+		APIParams
+		Username       string   `json:"username"`
+		Password       string   `json:"password"`
+		PasswordDigest string   `json:"password_digest"`
+		TOTPSecret     string   `json:"totp_secret"`
+		BackupCodes    []string `json:"backup_codes"`
+		Note           string   `json:"note"`
+	}{
+		Username:       "username",
+		Password:       "the-password",
+		PasswordDigest: "the-digest",
+		TOTPSecret:     "the-totp-secret",
+		BackupCodes:    []string{"the-backup-code"},
+		Note:           "leaked " + secretKey,
+	})
+	err := backend.Call(context.Background(), req, &testResource{})
+	require.NoError(t, err)
+
+	// Secrets never make it to the log output.
+	output := buf.String()
+	for _, secret := range []string{secretKey, "the-password", "the-digest", "the-totp-secret", "the-backup-code", "123456"} {
+		assert.NotContains(t, output, secret)
+	}
+
+	// There's one record for each attempt.
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Equal(t, 2, len(lines))
+	for i, line := range lines {
+		record := map[string]any{}
+		err := json.Unmarshal([]byte(line), &record)
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, record["method"])
+		assert.Equal(t, "/users", record["path"])
+		assert.Equal(t, "trace-id", record["trace_id"])
+		assert.Equal(t, float64(i+1), record["attempt"])
+		assert.Equal(t, req.IdempotencyKey, record["idempotency_key"])
+		assert.Contains(t, record, "duration")
+		assert.Contains(t, record["request_body"], `"username":"username"`)
+		assert.Contains(t, record["request_body"], `"password":"[REDACTED]"`)
+		headers, ok := record["request_headers"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, []any{"[REDACTED]"}, headers["Authorization"])
+	}
+	firstAttempt := map[string]any{}
+	err = json.Unmarshal([]byte(lines[0]), &firstAttempt)
+	require.NoError(t, err)
+	assert.Equal(t, "WARN", firstAttempt["level"])
+	assert.Equal(t, float64(http.StatusTooManyRequests), firstAttempt["status"])
+	assert.Equal(t, []any{"too_many_requests"}, firstAttempt["error_codes"])
+}
+
+func TestBackendCall_Logger_InfoLevel(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"id":"res_123"}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Logger:     slog.New(slog.NewJSONHandler(&buf, nil)),
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.NoError(t, err)
+
+	record := map[string]any{}
+	err = json.Unmarshal(buf.Bytes(), &record)
+	require.NoError(t, err)
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, float64(http.StatusOK), record["status"])
+	// Bodies and headers are only logged in debug level.
+	assert.NotContains(t, record, "request_headers")
+	assert.NotContains(t, record, "response_body")
+}
@@ -0,0 +1,86 @@
+package clerk
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Handler sends an API request to the Clerk API and returns the
+// API response.
+// The http.Request is the outgoing request, built from the
+// APIRequest. An error is returned for non-successful API responses,
+// but the APIResponse might still be available.
+type Handler func(*http.Request, *APIRequest) (*APIResponse, error)
+
+// Middleware can be used to intercept API requests made by the
+// Backend. A Middleware wraps the next Handler in the chain and
+// can inspect or modify the request before it's sent, as well as
+// the response and error after it's received.
+//
+//	func ExampleMiddleware(next clerk.Handler) clerk.Handler {
+//		return func(req *http.Request, apiReq *clerk.APIRequest) (*clerk.APIResponse, error) {
+//			// Before the request is sent.
+//			resp, err := next(req, apiReq)
+//			// After the response is received.
+//			return resp, err
+//		}
+//	}
+type Middleware func(next Handler) Handler
+
+// Wraps the handler with the provided middleware. The first
+// middleware will be the outermost one.
+func chainMiddleware(handler Handler, middleware ...Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] == nil {
+			continue
+		}
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// HeaderMiddleware returns a Middleware which adds the provided
+// headers to every API request. Existing values for the same
+// header keys will be replaced.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request, apiReq *APIRequest) (*APIResponse, error) {
+			for k, values := range header {
+				req.Header.Del(k)
+				for _, v := range values {
+					req.Header.Add(k, v)
+				}
+			}
+			return next(req, apiReq)
+		}
+	}
+}
+
+// Printfer can be used for printing messages. It's compatible with
+// the standard library's *log.Logger. For structured logging, set
+// the BackendConfig.Logger instead.
+type Printfer interface {
+	Printf(format string, v ...any)
+}
+
+// LoggingMiddleware returns a Middleware which logs one line for
+// every API request, with the request method and path, the response
+// status, the request duration and the Clerk trace ID.
+// Request headers and bodies are never logged, and secret keys are
+// redacted from errors.
+func LoggingMiddleware(logger Printfer) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request, apiReq *APIRequest) (*APIResponse, error) {
+			start := time.Now()
+			resp, err := next(req, apiReq)
+			duration := time.Since(start)
+			if resp == nil {
+				logger.Printf("clerk: %s %s failed after %s: %v", req.Method, req.URL.Path, duration, redactString(fmt.Sprint(err)))
+				return resp, err
+			}
+			logger.Printf("clerk: %s %s %d in %s trace_id=%s", req.Method, req.URL.Path, resp.StatusCode, duration, resp.TraceID)
+			return resp, err
+		}
+	}
+}
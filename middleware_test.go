@@ -0,0 +1,171 @@
+package clerk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCall_Middleware(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The middleware changed the Authorization header.
+		assert.Equal(t, "Bearer sk_test_other", r.Header.Get("Authorization"))
+		w.Header().Set("Clerk-Trace-Id", "trace-id")
+		_, err := w.Write([]byte(`{"id":"res_123"}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	var calls []string
+	tracer := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request, apiReq *APIRequest) (*APIResponse, error) {
+				calls = append(calls, "before "+name)
+				resp, err := next(req, apiReq)
+				calls = append(calls, "after "+name)
+				return resp, err
+			}
+		}
+	}
+	authSwap := func(next Handler) Handler {
+		return func(req *http.Request, apiReq *APIRequest) (*APIResponse, error) {
+			assert.Equal(t, http.MethodGet, apiReq.Method)
+			assert.Equal(t, "/resources", apiReq.Path)
+			req.Header.Set("Authorization", "Bearer sk_test_other")
+			resp, err := next(req, apiReq)
+			require.NoError(t, err)
+			assert.Equal(t, "trace-id", resp.TraceID)
+			return resp, err
+		}
+	}
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Key:        String("sk_test_123"),
+		Middleware: []Middleware{tracer("first"), tracer("second"), authSwap},
+	})
+	resource := &testResource{}
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), resource)
+	require.NoError(t, err)
+	assert.Equal(t, "res_123", resource.ID)
+	assert.Equal(t, []string{"before first", "before second", "after second", "after first"}, calls)
+}
+
+func TestBackendCall_Middleware_Error(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"errors":[{"code":"resource_not_found"}]}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	// The middleware can inspect API errors and replace them.
+	customErr := errors.New("custom")
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Middleware: []Middleware{
+			func(next Handler) Handler {
+				return func(req *http.Request, apiReq *APIRequest) (*APIResponse, error) {
+					resp, err := next(req, apiReq)
+					require.NotNil(t, resp)
+					assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+					_, ok := err.(*APIErrorResponse)
+					assert.True(t, ok)
+					return resp, customErr
+				}
+			},
+		},
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.Equal(t, customErr, err)
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "custom", r.Header.Get("X-Custom-Header"))
+		assert.Equal(t, "overriden", r.Header.Get("User-Agent"))
+		_, err := w.Write([]byte(`{}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Middleware: []Middleware{
+			HeaderMiddleware(http.Header{
+				"X-Custom-Header": []string{"custom"},
+				"User-Agent":      []string{"overriden"},
+			}),
+		},
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.NoError(t, err)
+}
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Clerk-Trace-Id", "trace-id")
+		w.WriteHeader(http.StatusCreated)
+		_, err := w.Write([]byte(`{}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	logger := &testLogger{}
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Key:        String("sk_test_123"),
+		Middleware: []Middleware{LoggingMiddleware(logger)},
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodPost, "/resources"), &testResource{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(logger.lines))
+	assert.Contains(t, logger.lines[0], "POST /resources 201")
+	assert.Contains(t, logger.lines[0], "trace_id=trace-id")
+	assert.NotContains(t, logger.lines[0], "sk_test_123")
+}
+
+func TestLoggingMiddleware_Error(t *testing.T) {
+	t.Parallel()
+	logger := &testLogger{}
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection failed for sk_test_123")
+		})},
+		Retry:      &RetryConfig{MaxAttempts: 1},
+		Middleware: []Middleware{LoggingMiddleware(logger)},
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.Error(t, err)
+	require.Equal(t, 1, len(logger.lines))
+	assert.Contains(t, logger.lines[0], "GET /v1/resources failed")
+	assert.NotContains(t, logger.lines[0], "sk_test_123")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -9,49 +9,60 @@ import (
 )
 
 // Create creates a new organization.
-func Create(ctx context.Context, params *CreateParams) (*clerk.Organization, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Get retrieves details for an organization.
 // The organization can be fetched by either the ID or its slug.
-func Get(ctx context.Context, idOrSlug string) (*clerk.Organization, error) {
-	return getClient().Get(ctx, idOrSlug)
+func Get(ctx context.Context, idOrSlug string, opts ...clerk.RequestOption) (*clerk.Organization, error) {
+	return getClient(ctx).Get(ctx, idOrSlug, opts...)
 }
 
 // Update updates an organization.
-func Update(ctx context.Context, id string, params *UpdateParams) (*clerk.Organization, error) {
-	return getClient().Update(ctx, id, params)
+func Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
+	return getClient(ctx).Update(ctx, id, params, opts...)
 }
 
 // UpdateMetadata updates the organization's metadata by merging the
 // provided values with the existing ones.
-func UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams) (*clerk.Organization, error) {
-	return getClient().UpdateMetadata(ctx, id, params)
+func UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
+	return getClient(ctx).UpdateMetadata(ctx, id, params, opts...)
 }
 
 // Delete deletes an organization.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
 // UpdateLogo sets or replaces the organization's logo.
-func UpdateLogo(ctx context.Context, id string, params *UpdateLogoParams) (*clerk.Organization, error) {
-	return getClient().UpdateLogo(ctx, id, params)
+func UpdateLogo(ctx context.Context, id string, params *UpdateLogoParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
+	return getClient(ctx).UpdateLogo(ctx, id, params, opts...)
 }
 
 // DeleteLogo removes the organization's logo.
-func DeleteLogo(ctx context.Context, id string) (*clerk.Organization, error) {
-	return getClient().DeleteLogo(ctx, id)
+func DeleteLogo(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Organization, error) {
+	return getClient(ctx).DeleteLogo(ctx, id, opts...)
 }
 
 // List returns a list of organizations.
-func List(ctx context.Context, params *ListParams) (*clerk.OrganizationList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+// ListAll returns an iterator over all organizations that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Organization] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
+}
+
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -40,8 +40,8 @@ type CreateParams struct {
 }
 
 // Create creates a new organization.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Organization, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	organization := &clerk.Organization{}
 	err := c.Backend.Call(ctx, req, organization)
@@ -50,12 +50,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Organ
 
 // Get retrieves details for an organization.
 // The organization can be fetched by either the ID or its slug.
-func (c *Client) Get(ctx context.Context, idOrSlug string) (*clerk.Organization, error) {
+func (c *Client) Get(ctx context.Context, idOrSlug string, opts ...clerk.RequestOption) (*clerk.Organization, error) {
 	path, err := clerk.JoinPath(path, idOrSlug)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	organization := &clerk.Organization{}
 	err = c.Backend.Call(ctx, req, organization)
 	return organization, err
@@ -72,12 +72,12 @@ type UpdateParams struct {
 }
 
 // Update updates an organization.
-func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*clerk.Organization, error) {
+func (c *Client) Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	organization := &clerk.Organization{}
 	err = c.Backend.Call(ctx, req, organization)
@@ -92,12 +92,12 @@ type UpdateMetadataParams struct {
 
 // UpdateMetadata updates the organization's metadata by merging the
 // provided values with the existing ones.
-func (c *Client) UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams) (*clerk.Organization, error) {
+func (c *Client) UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
 	path, err := clerk.JoinPath(path, id, "/metadata")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	organization := &clerk.Organization{}
 	err = c.Backend.Call(ctx, req, organization)
@@ -105,12 +105,12 @@ func (c *Client) UpdateMetadata(ctx context.Context, id string, params *UpdateMe
 }
 
 // Delete deletes an organization.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	organization := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, organization)
 	return organization, err
@@ -158,12 +158,12 @@ func (params *UpdateLogoParams) ToMultipart() ([]byte, string, error) {
 }
 
 // UpdateLogo sets or replaces the organization's logo.
-func (c *Client) UpdateLogo(ctx context.Context, id string, params *UpdateLogoParams) (*clerk.Organization, error) {
+func (c *Client) UpdateLogo(ctx context.Context, id string, params *UpdateLogoParams, opts ...clerk.RequestOption) (*clerk.Organization, error) {
 	path, err := clerk.JoinPath(path, id, "/logo")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewMultipartAPIRequest(http.MethodPut, path)
+	req := clerk.NewMultipartAPIRequest(http.MethodPut, path, opts...)
 	req.SetParams(params)
 	organization := &clerk.Organization{}
 	err = c.Backend.Call(ctx, req, organization)
@@ -171,12 +171,12 @@ func (c *Client) UpdateLogo(ctx context.Context, id string, params *UpdateLogoPa
 }
 
 // DeleteLogo removes the organization's logo.
-func (c *Client) DeleteLogo(ctx context.Context, id string) (*clerk.Organization, error) {
+func (c *Client) DeleteLogo(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Organization, error) {
 	path, err := clerk.JoinPath(path, id, "/logo")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	organization := &clerk.Organization{}
 	err = c.Backend.Call(ctx, req, organization)
 	return organization, err
@@ -210,10 +210,28 @@ func (params *ListParams) ToQuery() url.Values {
 }
 
 // List returns a list of organizations.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.OrganizationList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	list := &clerk.OrganizationList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
 }
+
+// ListAll returns an iterator over all organizations that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Organization] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.Organization, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Organizations, list.TotalCount, nil
+	})
+}
@@ -9,27 +9,38 @@ import (
 )
 
 // Create adds a new domain to the organization.
-func Create(ctx context.Context, organizationID string, params *CreateParams) (*clerk.OrganizationDomain, error) {
-	return getClient().Create(ctx, organizationID, params)
+func Create(ctx context.Context, organizationID string, params *CreateParams, opts ...clerk.RequestOption) (*clerk.OrganizationDomain, error) {
+	return getClient(ctx).Create(ctx, organizationID, params, opts...)
 }
 
 // Update updates an organization domain.
-func Update(ctx context.Context, params *UpdateParams) (*clerk.OrganizationDomain, error) {
-	return getClient().Update(ctx, params)
+func Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.OrganizationDomain, error) {
+	return getClient(ctx).Update(ctx, params, opts...)
 }
 
 // Delete removes a domain from an organization.
-func Delete(ctx context.Context, params *DeleteParams) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, params)
+func Delete(ctx context.Context, params *DeleteParams, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, params, opts...)
 }
 
 // List returns a list of organization domains.
-func List(ctx context.Context, organizationID string, params *ListParams) (*clerk.OrganizationDomainList, error) {
-	return getClient().List(ctx, organizationID, params)
+func List(ctx context.Context, organizationID string, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationDomainList, error) {
+	return getClient(ctx).List(ctx, organizationID, params, opts...)
 }
 
-func getClient() *Client {
+// ListAll returns an iterator over all organization domains that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, organizationID string, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationDomain] {
+	return getClient(ctx).ListAll(ctx, organizationID, params, opts...)
+}
+
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -33,12 +33,12 @@ type CreateParams struct {
 }
 
 // Create adds a new domain to the organization.
-func (c *Client) Create(ctx context.Context, organizationID string, params *CreateParams) (*clerk.OrganizationDomain, error) {
+func (c *Client) Create(ctx context.Context, organizationID string, params *CreateParams, opts ...clerk.RequestOption) (*clerk.OrganizationDomain, error) {
 	path, err := clerk.JoinPath(path, organizationID, "/domains")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	domain := &clerk.OrganizationDomain{}
 	err = c.Backend.Call(ctx, req, domain)
@@ -54,12 +54,12 @@ type UpdateParams struct {
 }
 
 // Update updates an organization domain.
-func (c *Client) Update(ctx context.Context, params *UpdateParams) (*clerk.OrganizationDomain, error) {
+func (c *Client) Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.OrganizationDomain, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/domains", params.DomainID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	domain := &clerk.OrganizationDomain{}
 	err = c.Backend.Call(ctx, req, domain)
@@ -73,12 +73,12 @@ type DeleteParams struct {
 }
 
 // Delete removes a domain from an organization.
-func (c *Client) Delete(ctx context.Context, params *DeleteParams) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, params *DeleteParams, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/domains", params.DomainID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	res := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, res)
 	return res, err
@@ -107,14 +107,32 @@ func (params *ListParams) ToQuery() url.Values {
 }
 
 // List returns a list of organization domains.
-func (c *Client) List(ctx context.Context, organizationID string, params *ListParams) (*clerk.OrganizationDomainList, error) {
+func (c *Client) List(ctx context.Context, organizationID string, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationDomainList, error) {
 	path, err := clerk.JoinPath(path, organizationID, "/domains")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	domains := &clerk.OrganizationDomainList{}
 	err = c.Backend.Call(ctx, req, domains)
 	return domains, err
 }
+
+// ListAll returns an iterator over all organization domains that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, organizationID string, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationDomain] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.OrganizationDomain, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, organizationID, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.OrganizationDomains, list.TotalCount, nil
+	})
+}
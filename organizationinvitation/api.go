@@ -9,32 +9,50 @@ import (
 )
 
 // Create creates and sends an invitation to join an organization.
-func Create(ctx context.Context, params *CreateParams) (*clerk.OrganizationInvitation, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitation, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // List returns a list of organization invitations
-func List(ctx context.Context, params *ListParams) (*clerk.OrganizationInvitationList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitationList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
+}
+
+// ListAll returns an iterator over all organization invitations that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationInvitation] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
 }
 
 // Get retrieves the detail for an organization invitation.
-func Get(ctx context.Context, params *GetParams) (*clerk.OrganizationInvitation, error) {
-	return getClient().Get(ctx, params)
+func Get(ctx context.Context, params *GetParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitation, error) {
+	return getClient(ctx).Get(ctx, params, opts...)
 }
 
 // Revoke marks the organization invitation as revoked.
-func Revoke(ctx context.Context, params *RevokeParams) (*clerk.OrganizationInvitation, error) {
-	return getClient().Revoke(ctx, params)
+func Revoke(ctx context.Context, params *RevokeParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitation, error) {
+	return getClient(ctx).Revoke(ctx, params, opts...)
 }
 
-// ListAllFromInstance lists all the organization invitations from the current instance
-func ListFromInstance(ctx context.Context, params *ListFromInstanceParams) (*clerk.OrganizationInvitationList, error) {
-	return getClient().ListFromInstance(ctx, params)
+// ListFromInstance lists all the organization invitations from the current instance
+func ListFromInstance(ctx context.Context, params *ListFromInstanceParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitationList, error) {
+	return getClient(ctx).ListFromInstance(ctx, params, opts...)
 }
 
-func getClient() *Client {
+// ListAllFromInstance returns an iterator over all organization
+// invitations from the current instance that match the provided
+// params. Pages of results are fetched as the iterator advances.
+func ListAllFromInstance(ctx context.Context, params *ListFromInstanceParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationInvitation] {
+	return getClient(ctx).ListAllFromInstance(ctx, params, opts...)
+}
+
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
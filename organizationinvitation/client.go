@@ -37,12 +37,12 @@ type CreateParams struct {
 }
 
 // Create creates and sends an invitation to join an organization.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.OrganizationInvitation, error) {
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitation, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/invitations")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	invitation := &clerk.OrganizationInvitation{}
 	err = c.Backend.Call(ctx, req, invitation)
@@ -67,30 +67,48 @@ func (p *ListParams) ToQuery() url.Values {
 }
 
 // List returns a list of organization invitations
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.OrganizationInvitationList, error) {
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitationList, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/invitations")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	invitation := &clerk.OrganizationInvitationList{}
 	err = c.Backend.Call(ctx, req, invitation)
 	return invitation, err
 }
 
+// ListAll returns an iterator over all organization invitations that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationInvitation] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.OrganizationInvitation, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.OrganizationInvitations, list.TotalCount, nil
+	})
+}
+
 type GetParams struct {
 	OrganizationID string
 	ID             string
 }
 
 // Get retrieves the detail for an organization invitation.
-func (c *Client) Get(ctx context.Context, params *GetParams) (*clerk.OrganizationInvitation, error) {
+func (c *Client) Get(ctx context.Context, params *GetParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitation, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/invitations", params.ID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	invitation := &clerk.OrganizationInvitation{}
 	err = c.Backend.Call(ctx, req, invitation)
 	return invitation, err
@@ -104,12 +122,12 @@ type RevokeParams struct {
 }
 
 // Revoke marks the organization invitation as revoked.
-func (c *Client) Revoke(ctx context.Context, params *RevokeParams) (*clerk.OrganizationInvitation, error) {
+func (c *Client) Revoke(ctx context.Context, params *RevokeParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitation, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/invitations", params.ID, "/revoke")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	invitation := &clerk.OrganizationInvitation{}
 	err = c.Backend.Call(ctx, req, invitation)
@@ -142,15 +160,33 @@ func (p *ListFromInstanceParams) ToQuery() url.Values {
 	return q
 }
 
-// ListAllFromInstance lists all the organization invitations from the current instance
-func (c *Client) ListFromInstance(ctx context.Context, params *ListFromInstanceParams) (*clerk.OrganizationInvitationList, error) {
+// ListFromInstance lists all the organization invitations from the current instance
+func (c *Client) ListFromInstance(ctx context.Context, params *ListFromInstanceParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitationList, error) {
 	path, err := clerk.JoinPath("/organization_invitations")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	invitation := &clerk.OrganizationInvitationList{}
 	err = c.Backend.Call(ctx, req, invitation)
 	return invitation, err
 }
+
+// ListAllFromInstance returns an iterator over all organization
+// invitations from the current instance that match the provided
+// params. Pages of results are fetched as the iterator advances.
+func (c *Client) ListAllFromInstance(ctx context.Context, params *ListFromInstanceParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationInvitation] {
+	if params == nil {
+		params = &ListFromInstanceParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.OrganizationInvitation, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.ListFromInstance(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.OrganizationInvitations, list.TotalCount, nil
+	})
+}
@@ -265,6 +265,31 @@ func TestOrganizationInvitationClientListFromInstance(t *testing.T) {
 	require.Equal(t, "orginv_123", response.OrganizationInvitations[0].ID)
 }
 
+func TestOrganizationInvitationClientListAllFromInstance(t *testing.T) {
+	t.Parallel()
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = &http.Client{
+		Transport: &clerktest.RoundTripper{
+			T:      t,
+			Out:    json.RawMessage(`{"data":[{"id":"orginv_123"}],"total_count":1}`),
+			Method: http.MethodGet,
+			Path:   "/v1/organization_invitations",
+			Query: &url.Values{
+				"limit":  []string{"10"},
+				"offset": []string{"0"},
+				"status": []string{"pending"},
+			},
+		},
+	}
+	client := NewClient(config)
+	params := &ListFromInstanceParams{Statuses: &[]string{"pending"}}
+	params.Limit = clerk.Int64(10)
+	invitations, err := client.ListAllFromInstance(context.Background(), params).All()
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	require.Equal(t, "orginv_123", invitations[0].ID)
+}
+
 func TestOrganizationInvitationClientListFromInstance_Error(t *testing.T) {
 	t.Parallel()
 	config := &clerk.ClientConfig{}
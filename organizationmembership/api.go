@@ -9,27 +9,38 @@ import (
 )
 
 // Create adds a new member to the organization.
-func Create(ctx context.Context, params *CreateParams) (*clerk.OrganizationMembership, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembership, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Update updates an organization membership.
-func Update(ctx context.Context, params *UpdateParams) (*clerk.OrganizationMembership, error) {
-	return getClient().Update(ctx, params)
+func Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembership, error) {
+	return getClient(ctx).Update(ctx, params, opts...)
 }
 
 // Delete removes a member from an organization.
-func Delete(ctx context.Context, params *DeleteParams) (*clerk.OrganizationMembership, error) {
-	return getClient().Delete(ctx, params)
+func Delete(ctx context.Context, params *DeleteParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembership, error) {
+	return getClient(ctx).Delete(ctx, params, opts...)
 }
 
 // List returns a list of organization memberships.
-func List(ctx context.Context, params *ListParams) (*clerk.OrganizationMembershipList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembershipList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+// ListAll returns an iterator over all organization memberships that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationMembership] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
+}
+
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
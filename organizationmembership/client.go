@@ -32,12 +32,12 @@ type CreateParams struct {
 }
 
 // Create adds a new member to the organization.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.OrganizationMembership, error) {
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembership, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/memberships")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	membership := &clerk.OrganizationMembership{}
 	err = c.Backend.Call(ctx, req, membership)
@@ -52,12 +52,12 @@ type UpdateParams struct {
 }
 
 // Update updates an organization membership.
-func (c *Client) Update(ctx context.Context, params *UpdateParams) (*clerk.OrganizationMembership, error) {
+func (c *Client) Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembership, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/memberships", params.UserID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	membership := &clerk.OrganizationMembership{}
 	err = c.Backend.Call(ctx, req, membership)
@@ -71,12 +71,12 @@ type DeleteParams struct {
 }
 
 // Delete removes a member from an organization.
-func (c *Client) Delete(ctx context.Context, params *DeleteParams) (*clerk.OrganizationMembership, error) {
+func (c *Client) Delete(ctx context.Context, params *DeleteParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembership, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/memberships", params.UserID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	membership := &clerk.OrganizationMembership{}
 	err = c.Backend.Call(ctx, req, membership)
 	return membership, err
@@ -128,14 +128,32 @@ func (params *ListParams) ToQuery() url.Values {
 }
 
 // List returns a list of organization memberships.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.OrganizationMembershipList, error) {
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembershipList, error) {
 	path, err := clerk.JoinPath(path, params.OrganizationID, "/memberships")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	list := &clerk.OrganizationMembershipList{}
 	err = c.Backend.Call(ctx, req, list)
 	return list, err
 }
+
+// ListAll returns an iterator over all organization memberships that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationMembership] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.OrganizationMembership, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.OrganizationMemberships, list.TotalCount, nil
+	})
+}
@@ -32,8 +32,8 @@ type CreateParams struct {
 }
 
 // Create creates a new phone number.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.PhoneNumber, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.PhoneNumber, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.PhoneNumber{}
 	err := c.Backend.Call(ctx, req, resource)
@@ -41,12 +41,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Phone
 }
 
 // Get retrieves a phone number.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.PhoneNumber, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.PhoneNumber, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	resource := &clerk.PhoneNumber{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -60,12 +60,12 @@ type UpdateParams struct {
 }
 
 // Update updates the phone number specified by id.
-func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*clerk.PhoneNumber, error) {
+func (c *Client) Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.PhoneNumber, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.PhoneNumber{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -73,12 +73,12 @@ func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*
 }
 
 // Delete deletes a phone number.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -12,12 +12,16 @@ import (
 //
 // Deprecated: The operation is deprecated and will be removed in
 // future versions.
-func Create(ctx context.Context, params *CreateParams) (*clerk.ProxyCheck, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.ProxyCheck, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
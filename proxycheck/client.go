@@ -36,8 +36,8 @@ type CreateParams struct {
 //
 // Deprecated: The operation is deprecated and will be removed in
 // future versions.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.ProxyCheck, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.ProxyCheck, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.ProxyCheck{}
 	err := c.Backend.Call(ctx, req, resource)
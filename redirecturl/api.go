@@ -9,27 +9,31 @@ import (
 )
 
 // Create creates a new redirect url.
-func Create(ctx context.Context, params *CreateParams) (*clerk.RedirectURL, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.RedirectURL, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Get retrieves details for a redirect url by ID.
-func Get(ctx context.Context, id string) (*clerk.RedirectURL, error) {
-	return getClient().Get(ctx, id)
+func Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.RedirectURL, error) {
+	return getClient(ctx).Get(ctx, id, opts...)
 }
 
 // Delete deletes a redirect url.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
 // List returns a list of redirect urls.
-func List(ctx context.Context, params *ListParams) (*clerk.RedirectURLList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.RedirectURLList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
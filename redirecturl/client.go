@@ -30,8 +30,8 @@ type CreateParams struct {
 }
 
 // Create creates a new redirect url.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.RedirectURL, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.RedirectURL, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	redirectURL := &clerk.RedirectURL{}
 	err := c.Backend.Call(ctx, req, redirectURL)
@@ -39,24 +39,24 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.Redir
 }
 
 // Get retrieves details for a redirect url by ID.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.RedirectURL, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.RedirectURL, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	redirectURL := &clerk.RedirectURL{}
 	err = c.Backend.Call(ctx, req, redirectURL)
 	return redirectURL, err
 }
 
 // Delete deletes a redirect url.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	redirectURL := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, redirectURL)
 	return redirectURL, err
@@ -67,8 +67,8 @@ type ListParams struct {
 }
 
 // List returns a list of redirect urls.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.RedirectURLList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path))
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.RedirectURLList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path), opts...)
 	list := &clerk.RedirectURLList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
@@ -0,0 +1,170 @@
+package clerk
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff     = 8 * time.Second
+)
+
+// RetryConfig describes the policy for retrying failed requests to
+// the Clerk API.
+// Requests are retried on network errors, on 429 Too Many Requests
+// responses and on 500, 502, 503 and 504 server errors.
+// Only idempotent requests are retried by default.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of times a request will be
+	// sent, including the first attempt.
+	// Defaults to 3.
+	MaxAttempts int
+	// InitialBackoff is the base wait time before the first retry.
+	// The wait time doubles for each subsequent retry and a random
+	// jitter is applied to it.
+	// Defaults to 500 milliseconds.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum wait time between two attempts.
+	// If the Clerk API responds with a Retry-After header that asks
+	// for a longer wait, the request will not be retried.
+	// Defaults to 8 seconds.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows retries for requests with methods
+	// that are not idempotent, like POST and PATCH.
+	// Such requests carry an idempotency key which stays the same
+	// across attempts, so that the Clerk API can detect duplicates.
+	RetryNonIdempotent bool
+}
+
+// Returns a copy of the RetryConfig with default values for any
+// fields that were not set.
+func (config RetryConfig) withDefaults() *RetryConfig {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+	return &config
+}
+
+// shouldRetry returns true if the request can be sent again, based
+// on the outcome of the previous attempt.
+// The attempt argument is the number of attempts made so far.
+func (config *RetryConfig) shouldRetry(req *http.Request, resp *APIResponse, err error, attempt int) bool {
+	if config == nil || attempt >= config.MaxAttempts {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if !config.RetryNonIdempotent && !isIdempotent(req) {
+		return false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// We can't replay the request body.
+		return false
+	}
+	if err != nil {
+		// Network errors are always worth another attempt.
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the duration to wait before sending the next
+// attempt. Returns false if the request should not be retried,
+// because the server asked for a wait time longer than the maximum
+// allowed backoff.
+func (config *RetryConfig) backoff(resp *APIResponse, attempt int) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			return wait, wait <= config.MaxBackoff
+		}
+	}
+	wait := config.InitialBackoff
+	for i := 1; i < attempt && wait < config.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > config.MaxBackoff {
+		wait = config.MaxBackoff
+	}
+	// Apply equal jitter, so that we wait for at least half of the
+	// calculated backoff.
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// Parses the Retry-After header for 429 and 503 responses. The
+// header value can either be a number of seconds or an HTTP date.
+func retryAfter(resp *APIResponse) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		// Avoid overflowing the duration for huge values.
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return math.MaxInt64, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// Idempotent methods, as defined in RFC 9110.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Blocks for the provided duration, or until the context is done.
+// Returns false if the context was done before the time elapsed.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,244 @@
+package clerk
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCall_Retry(t *testing.T) {
+	t.Parallel()
+	var totalRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first two requests.
+		if atomic.AddInt32(&totalRequests, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, err := w.Write([]byte(`{"id":"res_123"}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Retry: &RetryConfig{
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	resource := &testResource{}
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), resource)
+	require.NoError(t, err)
+	assert.Equal(t, "res_123", resource.ID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&totalRequests))
+}
+
+func TestBackendCall_Retry_MaxAttempts(t *testing.T) {
+	t.Parallel()
+	var totalRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&totalRequests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, err := w.Write([]byte(`{"errors":[{"code":"unavailable"}]}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Retry: &RetryConfig{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.Error(t, err)
+	apiErr, ok := err.(*APIErrorResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.HTTPStatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&totalRequests))
+}
+
+func TestBackendCall_Retry_NonIdempotent(t *testing.T) {
+	t.Parallel()
+	var totalRequests int32
+	idempotencyKeys := &sync.Map{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The request body is replayed on every attempt.
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"the-name"}`, string(body))
+		// The idempotency key stays the same across attempts.
+		idempotencyKeys.Store(r.Header.Get("Idempotency-Key"), struct{}{})
+
+		if atomic.AddInt32(&totalRequests, 1) < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write([]byte(`{}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	// POST requests are not retried by default.
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Retry: &RetryConfig{
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	req := NewAPIRequest(http.MethodPost, "/resources")
+	req.SetParams(&testResourceParams{Name: "the-name"})
+	err := backend.Call(context.Background(), req, &testResource{})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&totalRequests))
+
+	// Unless explicitly allowed.
+	atomic.StoreInt32(&totalRequests, 0)
+	idempotencyKeys = &sync.Map{}
+	backend = NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Retry: &RetryConfig{
+			InitialBackoff:     time.Millisecond,
+			RetryNonIdempotent: true,
+		},
+	})
+	req = NewAPIRequest(http.MethodPost, "/resources")
+	req.SetParams(&testResourceParams{Name: "the-name"})
+	resource := &testResource{}
+	err = backend.Call(context.Background(), req, resource)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&totalRequests))
+	totalKeys := 0
+	idempotencyKeys.Range(func(k, _ any) bool {
+		totalKeys++
+		assert.Equal(t, resource.Response.IdempotencyKey, k)
+		return true
+	})
+	assert.Equal(t, 1, totalKeys)
+}
+
+func TestBackendCall_Retry_ClientErrors(t *testing.T) {
+	t.Parallel()
+	var totalRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&totalRequests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Retry: &RetryConfig{
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&totalRequests))
+}
+
+func TestBackendCall_Retry_RetryAfter(t *testing.T) {
+	t.Parallel()
+	var totalRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&totalRequests, 1) < 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, err := w.Write([]byte(`{}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	// The server asks for a longer wait than the maximum backoff.
+	backend := NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Retry: &RetryConfig{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+		},
+	})
+	err := backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&totalRequests))
+
+	// The Retry-After header is respected.
+	atomic.StoreInt32(&totalRequests, 0)
+	backend = NewBackend(&BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        &ts.URL,
+		Retry: &RetryConfig{
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	start := time.Now()
+	err = backend.Call(context.Background(), NewAPIRequest(http.MethodGet, "/resources"), &testResource{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&totalRequests))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetryConfigBackoff(t *testing.T) {
+	t.Parallel()
+	config := RetryConfig{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}.withDefaults()
+	assert.Equal(t, defaultRetryMaxAttempts, config.MaxAttempts)
+	for _, tc := range []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 1, max: 100 * time.Millisecond},
+		{attempt: 2, max: 200 * time.Millisecond},
+		{attempt: 3, max: 400 * time.Millisecond},
+		{attempt: 4, max: 800 * time.Millisecond},
+		{attempt: 5, max: time.Second},
+		{attempt: 10, max: time.Second},
+	} {
+		wait, ok := config.backoff(nil, tc.attempt)
+		require.True(t, ok)
+		assert.GreaterOrEqual(t, wait, tc.max/2)
+		assert.LessOrEqual(t, wait, tc.max)
+	}
+}
+
+func TestRetryConfigBackoff_RetryAfter(t *testing.T) {
+	t.Parallel()
+	config := RetryConfig{MaxBackoff: time.Minute}.withDefaults()
+	for _, tc := range []struct {
+		retryAfter string
+		wait       time.Duration
+		ok         bool
+	}{
+		{retryAfter: "30", wait: 30 * time.Second, ok: true},
+		{retryAfter: "120", wait: 2 * time.Minute, ok: false},
+		// Would overflow time.Duration without clamping.
+		{retryAfter: "9223372036854775807", wait: math.MaxInt64, ok: false},
+	} {
+		resp := &APIResponse{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{tc.retryAfter}},
+		}
+		wait, ok := config.backoff(resp, 1)
+		assert.Equal(t, tc.wait, wait)
+		assert.Equal(t, tc.ok, ok)
+	}
+}
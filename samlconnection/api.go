@@ -9,32 +9,43 @@ import (
 )
 
 // Create creates a new SAML Connection.
-func Create(ctx context.Context, params *CreateParams) (*clerk.SAMLConnection, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.SAMLConnection, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Get returns details about a SAML Connection.
-func Get(ctx context.Context, id string) (*clerk.SAMLConnection, error) {
-	return getClient().Get(ctx, id)
+func Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.SAMLConnection, error) {
+	return getClient(ctx).Get(ctx, id, opts...)
 }
 
 // Update updates the SAML Connection specified by id.
-func Update(ctx context.Context, id string, params *UpdateParams) (*clerk.SAMLConnection, error) {
-	return getClient().Update(ctx, id, params)
+func Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.SAMLConnection, error) {
+	return getClient(ctx).Update(ctx, id, params, opts...)
 }
 
 // Delete deletes a SAML Connection.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
 // List returns a list of SAML Connections.
-func List(ctx context.Context, params *ListParams) (*clerk.SAMLConnectionList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.SAMLConnectionList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+// ListAll returns an iterator over all SAML connections that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.SAMLConnection] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
+}
+
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
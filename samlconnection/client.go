@@ -46,8 +46,8 @@ type CreateParams struct {
 }
 
 // Create creates a new SAML Connection.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.SAMLConnection, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.SAMLConnection, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	connection := &clerk.SAMLConnection{}
 	err := c.Backend.Call(ctx, req, connection)
@@ -55,12 +55,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.SAMLC
 }
 
 // Get returns details about a SAML Connection.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.SAMLConnection, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.SAMLConnection, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	connection := &clerk.SAMLConnection{}
 	err = c.Backend.Call(ctx, req, connection)
 	return connection, err
@@ -89,12 +89,12 @@ type UpdateParams struct {
 }
 
 // Update updates the SAML Connection specified by id.
-func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*clerk.SAMLConnection, error) {
+func (c *Client) Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.SAMLConnection, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	connection := &clerk.SAMLConnection{}
 	err = c.Backend.Call(ctx, req, connection)
@@ -102,12 +102,12 @@ func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*
 }
 
 // Delete deletes a SAML Connection.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	template := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, template)
 	return template, err
@@ -133,10 +133,28 @@ func (params *ListParams) ToQuery() url.Values {
 }
 
 // List returns a list of SAML Connections.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.SAMLConnectionList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.SAMLConnectionList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	list := &clerk.SAMLConnectionList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
 }
+
+// ListAll returns an iterator over all SAML connections that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.SAMLConnection] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.SAMLConnection, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.SAMLConnections, list.TotalCount, nil
+	})
+}
@@ -9,18 +9,25 @@ import (
 )
 
 // Get retrieves details for a session.
-func Get(ctx context.Context, id string) (*clerk.Session, error) {
-	return getClient().Get(ctx, id)
+func Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Session, error) {
+	return getClient(ctx).Get(ctx, id, opts...)
 }
 
 // List returns a list of sessions.
-func List(ctx context.Context, params *ListParams) (*clerk.SessionList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.SessionList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
+}
+
+// ListAll returns an iterator over all sessions that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Session] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
 }
 
 // Revoke marks the session as revoked.
-func Revoke(ctx context.Context, params *RevokeParams) (*clerk.Session, error) {
-	return getClient().Revoke(ctx, params)
+func Revoke(ctx context.Context, params *RevokeParams, opts ...clerk.RequestOption) (*clerk.Session, error) {
+	return getClient(ctx).Revoke(ctx, params, opts...)
 }
 
 // Verify verifies the session.
@@ -29,12 +36,16 @@ func Revoke(ctx context.Context, params *RevokeParams) (*clerk.Session, error) {
 // It is recommended to switch to networkless verification using short-lived
 // session tokens instead.
 // See https://clerk.com/docs/backend-requests/resources/session-tokens
-func Verify(ctx context.Context, params *VerifyParams) (*clerk.Session, error) {
-	return getClient().Verify(ctx, params)
+func Verify(ctx context.Context, params *VerifyParams, opts ...clerk.RequestOption) (*clerk.Session, error) {
+	return getClient(ctx).Verify(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -26,12 +26,12 @@ func NewClient(config *clerk.ClientConfig) *Client {
 }
 
 // Get retrieves details for a session.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.Session, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.Session, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	session := &clerk.Session{}
 	err = c.Backend.Call(ctx, req, session)
 	return session, err
@@ -61,25 +61,43 @@ func (params *ListParams) ToQuery() url.Values {
 }
 
 // List returns a list of sessions.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.SessionList, error) {
-	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path))
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.SessionList, error) {
+	req := clerk.NewAPIRequest(http.MethodGet, fmt.Sprintf("%s?paginated=true", path), opts...)
 	req.SetParams(params)
 	list := &clerk.SessionList{}
 	err := c.Backend.Call(ctx, req, list)
 	return list, err
 }
 
+// ListAll returns an iterator over all sessions that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.Session] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.Session, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Sessions, list.TotalCount, nil
+	})
+}
+
 type RevokeParams struct {
 	ID string `json:"id"`
 }
 
 // Revoke marks the session as revoked.
-func (c *Client) Revoke(ctx context.Context, params *RevokeParams) (*clerk.Session, error) {
+func (c *Client) Revoke(ctx context.Context, params *RevokeParams, opts ...clerk.RequestOption) (*clerk.Session, error) {
 	path, err := clerk.JoinPath(path, params.ID, "/revoke")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	session := &clerk.Session{}
 	err = c.Backend.Call(ctx, req, session)
 	return session, err
@@ -96,12 +114,12 @@ type VerifyParams struct {
 // It is recommended to switch to networkless verification using short-lived
 // session tokens instead.
 // See https://clerk.com/docs/backend-requests/resources/session-tokens
-func (c *Client) Verify(ctx context.Context, params *VerifyParams) (*clerk.Session, error) {
+func (c *Client) Verify(ctx context.Context, params *VerifyParams, opts ...clerk.RequestOption) (*clerk.Session, error) {
 	path, err := clerk.JoinPath(path, params.ID, "/verify")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	session := &clerk.Session{}
 	err = c.Backend.Call(ctx, req, session)
 	return session, err
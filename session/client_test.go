@@ -109,3 +109,29 @@ func TestSessionClientVerify(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, id, session.ID)
 }
+
+func TestSessionClientListAll(t *testing.T) {
+	t.Parallel()
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = &http.Client{
+		Transport: &clerktest.RoundTripper{
+			T:      t,
+			Out:    json.RawMessage(`{"data":[{"id":"sess_123"}],"total_count":1}`),
+			Method: http.MethodGet,
+			Path:   "/v1/sessions",
+			Query: &url.Values{
+				"limit":     []string{"10"},
+				"offset":    []string{"0"},
+				"paginated": []string{"true"},
+				"status":    []string{"active"},
+			},
+		},
+	}
+	client := NewClient(config)
+	params := &ListParams{Status: clerk.String("active")}
+	params.Limit = clerk.Int64(10)
+	sessions, err := client.ListAll(context.Background(), params).All()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, "sess_123", sessions[0].ID)
+}
@@ -30,8 +30,8 @@ type CreateParams struct {
 }
 
 // Create creates a new sign-in token.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.SignInToken, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.SignInToken, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	token := &clerk.SignInToken{}
 	err := c.Backend.Call(ctx, req, token)
@@ -39,13 +39,13 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.SignI
 }
 
 // Revoke revokes a pending sign-in token.
-func (c *Client) Revoke(ctx context.Context, id string) (*clerk.SignInToken, error) {
+func (c *Client) Revoke(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.SignInToken, error) {
 	token := &clerk.SignInToken{}
 	path, err := clerk.JoinPath(path, id, "revoke")
 	if err != nil {
 		return token, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	err = c.Backend.Call(ctx, req, token)
 	return token, err
 }
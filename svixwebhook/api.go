@@ -9,22 +9,26 @@ import (
 )
 
 // Create creates a Svix app.
-func Create(ctx context.Context) (*clerk.SvixWebhook, error) {
-	return getClient().Create(ctx)
+func Create(ctx context.Context, opts ...clerk.RequestOption) (*clerk.SvixWebhook, error) {
+	return getClient(ctx).Create(ctx, opts...)
 }
 
 // Delete deletes the Svix app.
-func Delete(ctx context.Context) (*clerk.SvixWebhook, error) {
-	return getClient().Delete(ctx)
+func Delete(ctx context.Context, opts ...clerk.RequestOption) (*clerk.SvixWebhook, error) {
+	return getClient(ctx).Delete(ctx, opts...)
 }
 
 // RefreshURL generates a new URL for accessing Svix's dashboard.
-func RefreshURL(ctx context.Context) (*clerk.SvixWebhook, error) {
-	return getClient().RefreshURL(ctx)
+func RefreshURL(ctx context.Context, opts ...clerk.RequestOption) (*clerk.SvixWebhook, error) {
+	return getClient(ctx).RefreshURL(ctx, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
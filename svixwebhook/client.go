@@ -24,24 +24,24 @@ func NewClient(config *clerk.ClientConfig) *Client {
 }
 
 // Create creates a Svix app.
-func (c *Client) Create(ctx context.Context) (*clerk.SvixWebhook, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, opts ...clerk.RequestOption) (*clerk.SvixWebhook, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	resource := &clerk.SvixWebhook{}
 	err := c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // Delete deletes the Svix app.
-func (c *Client) Delete(ctx context.Context) (*clerk.SvixWebhook, error) {
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+func (c *Client) Delete(ctx context.Context, opts ...clerk.RequestOption) (*clerk.SvixWebhook, error) {
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.SvixWebhook{}
 	err := c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // RefreshURL generates a new URL for accessing Svix's dashboard.
-func (c *Client) RefreshURL(ctx context.Context) (*clerk.SvixWebhook, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, "/webhooks/svix_url")
+func (c *Client) RefreshURL(ctx context.Context, opts ...clerk.RequestOption) (*clerk.SvixWebhook, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, "/webhooks/svix_url", opts...)
 	resource := &clerk.SvixWebhook{}
 	err := c.Backend.Call(ctx, req, resource)
 	return resource, err
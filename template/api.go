@@ -9,43 +9,47 @@ import (
 )
 
 // Get retrieves details for a template.
-func Get(ctx context.Context, params *GetParams) (*clerk.Template, error) {
-	return getClient().Get(ctx, params)
+func Get(ctx context.Context, params *GetParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
+	return getClient(ctx).Get(ctx, params, opts...)
 }
 
 // Update updates an existing template or creates a new one with the
 // provided params.
-func Update(ctx context.Context, params *UpdateParams) (*clerk.Template, error) {
-	return getClient().Update(ctx, params)
+func Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
+	return getClient(ctx).Update(ctx, params, opts...)
 }
 
 // Delete deletes a custom user template.
-func Delete(ctx context.Context, params *DeleteParams) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, params)
+func Delete(ctx context.Context, params *DeleteParams, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, params, opts...)
 }
 
 // Revert reverts a template to its default state.
-func Revert(ctx context.Context, params *RevertParams) (*clerk.Template, error) {
-	return getClient().Revert(ctx, params)
+func Revert(ctx context.Context, params *RevertParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
+	return getClient(ctx).Revert(ctx, params, opts...)
 }
 
 // ToggleDelivery sets the delivery by Clerk for a template.
-func ToggleDelivery(ctx context.Context, params *ToggleDeliveryParams) (*clerk.Template, error) {
-	return getClient().ToggleDelivery(ctx, params)
+func ToggleDelivery(ctx context.Context, params *ToggleDeliveryParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
+	return getClient(ctx).ToggleDelivery(ctx, params, opts...)
 }
 
 // Preview returns a preview of a template.
-func Preview(ctx context.Context, params *PreviewParams) (*clerk.TemplatePreview, error) {
-	return getClient().Preview(ctx, params)
+func Preview(ctx context.Context, params *PreviewParams, opts ...clerk.RequestOption) (*clerk.TemplatePreview, error) {
+	return getClient(ctx).Preview(ctx, params, opts...)
 }
 
 // List returns a list of templates of a given type.
-func List(ctx context.Context, params *ListParams) (*clerk.TemplateList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.TemplateList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
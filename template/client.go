@@ -30,12 +30,12 @@ type GetParams struct {
 }
 
 // Get retrieves details for a template.
-func (c *Client) Get(ctx context.Context, params *GetParams) (*clerk.Template, error) {
+func (c *Client) Get(ctx context.Context, params *GetParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
 	path, err := clerk.JoinPath(path, string(params.TemplateType), params.Slug)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	resource := &clerk.Template{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -56,12 +56,12 @@ type UpdateParams struct {
 
 // Update updates an existing template or creates a new one with the
 // provided params.
-func (c *Client) Update(ctx context.Context, params *UpdateParams) (*clerk.Template, error) {
+func (c *Client) Update(ctx context.Context, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
 	path, err := clerk.JoinPath(path, string(params.TemplateType), params.Slug)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPut, path)
+	req := clerk.NewAPIRequest(http.MethodPut, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.Template{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -74,12 +74,12 @@ type DeleteParams struct {
 }
 
 // Delete deletes a custom user template.
-func (c *Client) Delete(ctx context.Context, params *DeleteParams) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, params *DeleteParams, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, string(params.TemplateType), params.Slug)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -91,12 +91,12 @@ type RevertParams struct {
 }
 
 // Revert reverts a template to its default state.
-func (c *Client) Revert(ctx context.Context, params *RevertParams) (*clerk.Template, error) {
+func (c *Client) Revert(ctx context.Context, params *RevertParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
 	path, err := clerk.JoinPath(path, string(params.TemplateType), params.Slug, "/revert")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	resource := &clerk.Template{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -110,12 +110,12 @@ type ToggleDeliveryParams struct {
 }
 
 // ToggleDelivery sets the delivery by Clerk for a template.
-func (c *Client) ToggleDelivery(ctx context.Context, params *ToggleDeliveryParams) (*clerk.Template, error) {
+func (c *Client) ToggleDelivery(ctx context.Context, params *ToggleDeliveryParams, opts ...clerk.RequestOption) (*clerk.Template, error) {
 	path, err := clerk.JoinPath(path, string(params.TemplateType), params.Slug, "/toggle_delivery")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.Template{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -133,12 +133,12 @@ type PreviewParams struct {
 }
 
 // Preview returns a preview of a template.
-func (c *Client) Preview(ctx context.Context, params *PreviewParams) (*clerk.TemplatePreview, error) {
+func (c *Client) Preview(ctx context.Context, params *PreviewParams, opts ...clerk.RequestOption) (*clerk.TemplatePreview, error) {
 	path, err := clerk.JoinPath(path, string(params.TemplateType), params.Slug, "/preview")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.TemplatePreview{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -150,12 +150,12 @@ type ListParams struct {
 }
 
 // List returns a list of templates of a given type.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.TemplateList, error) {
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.TemplateList, error) {
 	path, err := clerk.JoinPath(path, fmt.Sprintf("%s?paginated=true", params.TemplateType))
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	list := &clerk.TemplateList{}
 	err = c.Backend.Call(ctx, req, list)
 	return list, err
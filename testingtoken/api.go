@@ -9,12 +9,16 @@ import (
 )
 
 // Create creates a new testing token.
-func Create(ctx context.Context) (*clerk.TestingToken, error) {
-	return getClient().Create(ctx)
+func Create(ctx context.Context, opts ...clerk.RequestOption) (*clerk.TestingToken, error) {
+	return getClient(ctx).Create(ctx, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
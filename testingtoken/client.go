@@ -26,8 +26,8 @@ func NewClient(config *clerk.ClientConfig) *Client {
 }
 
 // Create creates a new testing token.
-func (c *Client) Create(ctx context.Context) (*clerk.TestingToken, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, opts ...clerk.RequestOption) (*clerk.TestingToken, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	token := &clerk.TestingToken{}
 	err := c.Backend.Call(ctx, req, token)
 	return token, err
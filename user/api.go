@@ -9,124 +9,149 @@ import (
 )
 
 // Create creates a new user.
-func Create(ctx context.Context, params *CreateParams) (*clerk.User, error) {
-	return getClient().Create(ctx, params)
+func Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).Create(ctx, params, opts...)
 }
 
 // Get retrieves details about the user.
-func Get(ctx context.Context, id string) (*clerk.User, error) {
-	return getClient().Get(ctx, id)
+func Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).Get(ctx, id, opts...)
 }
 
 // Update updates a user.
-func Update(ctx context.Context, id string, params *UpdateParams) (*clerk.User, error) {
-	return getClient().Update(ctx, id, params)
+func Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).Update(ctx, id, params, opts...)
 }
 
 // UpdateProfileImage sets or replaces the user's profile image.
-func UpdateProfileImage(ctx context.Context, id string, params *UpdateProfileImageParams) (*clerk.User, error) {
-	return getClient().UpdateProfileImage(ctx, id, params)
+func UpdateProfileImage(ctx context.Context, id string, params *UpdateProfileImageParams, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).UpdateProfileImage(ctx, id, params, opts...)
 }
 
 // DeleteProfileImage deletes the user's profile image.
-func DeleteProfileImage(ctx context.Context, id string) (*clerk.User, error) {
-	return getClient().DeleteProfileImage(ctx, id)
+func DeleteProfileImage(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).DeleteProfileImage(ctx, id, opts...)
 }
 
 // UpdateMetadata updates the user's metadata by merging the
 // provided values with the existing ones.
-func UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams) (*clerk.User, error) {
-	return getClient().UpdateMetadata(ctx, id, params)
+func UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).UpdateMetadata(ctx, id, params, opts...)
 }
 
 // Delete deletes a user.
-func Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
-	return getClient().Delete(ctx, id)
+func Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).Delete(ctx, id, opts...)
 }
 
 // List returns a list of users.
-func List(ctx context.Context, params *ListParams) (*clerk.UserList, error) {
-	return getClient().List(ctx, params)
+func List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.UserList, error) {
+	return getClient(ctx).List(ctx, params, opts...)
+}
+
+// ListAll returns an iterator over all users that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.User] {
+	return getClient(ctx).ListAll(ctx, params, opts...)
 }
 
 // Count returns the total count of users satisfying the parameters.
-func Count(ctx context.Context, params *ListParams) (*TotalCount, error) {
-	return getClient().Count(ctx, params)
+func Count(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*TotalCount, error) {
+	return getClient(ctx).Count(ctx, params, opts...)
 }
 
 // ListOAuthAccessTokens retrieves a list of the user's access
 // tokens for a specific OAuth provider.
-func ListOAuthAccessTokens(ctx context.Context, params *ListOAuthAccessTokensParams) (*clerk.OAuthAccessTokenList, error) {
-	return getClient().ListOAuthAccessTokens(ctx, params)
+func ListOAuthAccessTokens(ctx context.Context, params *ListOAuthAccessTokensParams, opts ...clerk.RequestOption) (*clerk.OAuthAccessTokenList, error) {
+	return getClient(ctx).ListOAuthAccessTokens(ctx, params, opts...)
 }
 
 // DeleteMFA disables a user's multi-factor authentication methods.
-func DeleteMFA(ctx context.Context, params *DeleteMFAParams) (*MultifactorAuthentication, error) {
-	return getClient().DeleteMFA(ctx, params)
+func DeleteMFA(ctx context.Context, params *DeleteMFAParams, opts ...clerk.RequestOption) (*MultifactorAuthentication, error) {
+	return getClient(ctx).DeleteMFA(ctx, params, opts...)
 }
 
 // Ban marks the user as banned.
-func Ban(ctx context.Context, id string) (*clerk.User, error) {
-	return getClient().Ban(ctx, id)
+func Ban(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).Ban(ctx, id, opts...)
 }
 
 // Unban removes the ban for a user.
-func Unban(ctx context.Context, id string) (*clerk.User, error) {
-	return getClient().Unban(ctx, id)
+func Unban(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).Unban(ctx, id, opts...)
 }
 
 // Lock marks the user as locked.
-func Lock(ctx context.Context, id string) (*clerk.User, error) {
-	return getClient().Lock(ctx, id)
+func Lock(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).Lock(ctx, id, opts...)
 }
 
 // Unlock removes the lock for a user.
-func Unlock(ctx context.Context, id string) (*clerk.User, error) {
-	return getClient().Unlock(ctx, id)
+func Unlock(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
+	return getClient(ctx).Unlock(ctx, id, opts...)
 }
 
 // ListOrganizationMemberships lists all the user's organization memberships.
-func ListOrganizationMemberships(ctx context.Context, id string, params *ListOrganizationMembershipsParams) (*clerk.OrganizationMembershipList, error) {
-	return getClient().ListOrganizationMemberships(ctx, id, params)
+func ListOrganizationMemberships(ctx context.Context, id string, params *ListOrganizationMembershipsParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembershipList, error) {
+	return getClient(ctx).ListOrganizationMemberships(ctx, id, params, opts...)
+}
+
+// ListAllOrganizationMemberships returns an iterator over all the
+// user's organization memberships. Pages of results are fetched as
+// the iterator advances.
+func ListAllOrganizationMemberships(ctx context.Context, id string, params *ListOrganizationMembershipsParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationMembership] {
+	return getClient(ctx).ListAllOrganizationMemberships(ctx, id, params, opts...)
 }
 
 // ListOrganizationInvitations lists all the user's organization invitations.
-func ListOrganizationInvitations(ctx context.Context, params *ListOrganizationInvitationsParams) (*clerk.OrganizationInvitationList, error) {
-	return getClient().ListOrganizationInvitations(ctx, params)
+func ListOrganizationInvitations(ctx context.Context, params *ListOrganizationInvitationsParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitationList, error) {
+	return getClient(ctx).ListOrganizationInvitations(ctx, params, opts...)
+}
+
+// ListAllOrganizationInvitations returns an iterator over all the
+// user's organization invitations that match the provided params.
+// Pages of results are fetched as the iterator advances.
+func ListAllOrganizationInvitations(ctx context.Context, params *ListOrganizationInvitationsParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationInvitation] {
+	return getClient(ctx).ListAllOrganizationInvitations(ctx, params, opts...)
 }
 
 // DeletePasskey deletes a passkey by its identification ID.
-func DeletePasskey(ctx context.Context, userID, identificationID string) (*clerk.DeletedResource, error) {
-	return getClient().DeletePasskey(ctx, userID, identificationID)
+func DeletePasskey(ctx context.Context, userID, identificationID string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).DeletePasskey(ctx, userID, identificationID, opts...)
 }
 
 // DeleteWeb3Wallet deletes a web3 wallet by its identification ID.
-func DeleteWeb3Wallet(ctx context.Context, userID, identificationID string) (*clerk.DeletedResource, error) {
-	return getClient().DeleteWeb3Wallet(ctx, userID, identificationID)
+func DeleteWeb3Wallet(ctx context.Context, userID, identificationID string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).DeleteWeb3Wallet(ctx, userID, identificationID, opts...)
 }
 
 // CreateTOTP creates a TOTP (Time-based One-Time Password) for the user.
-func CreateTOTP(ctx context.Context, userID string) (*clerk.TOTP, error) {
-	return getClient().CreateTOTP(ctx, userID)
+func CreateTOTP(ctx context.Context, userID string, opts ...clerk.RequestOption) (*clerk.TOTP, error) {
+	return getClient(ctx).CreateTOTP(ctx, userID, opts...)
 }
 
 // DeleteTOTP deletes all the TOTPs from a given user.
-func DeleteTOTP(ctx context.Context, userID string) (*MultifactorAuthentication, error) {
-	return getClient().DeleteTOTP(ctx, userID)
+func DeleteTOTP(ctx context.Context, userID string, opts ...clerk.RequestOption) (*MultifactorAuthentication, error) {
+	return getClient(ctx).DeleteTOTP(ctx, userID, opts...)
 }
 
 // DeleteBackupCode deletes all the backup codes from a given user.
-func DeleteBackupCode(ctx context.Context, userID string) (*MultifactorAuthentication, error) {
-	return getClient().DeleteBackupCode(ctx, userID)
+func DeleteBackupCode(ctx context.Context, userID string, opts ...clerk.RequestOption) (*MultifactorAuthentication, error) {
+	return getClient(ctx).DeleteBackupCode(ctx, userID, opts...)
 }
 
 // DeleteExternalAccount deletes an external account by its ID.
-func DeleteExternalAccount(ctx context.Context, params *DeleteExternalAccountParams) (*clerk.DeletedResource, error) {
-	return getClient().DeleteExternalAccount(ctx, params)
+func DeleteExternalAccount(ctx context.Context, params *DeleteExternalAccountParams, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
+	return getClient(ctx).DeleteExternalAccount(ctx, params, opts...)
 }
 
-func getClient() *Client {
+func getClient(ctx context.Context) *Client {
+	backend, ok := clerk.BackendFromContext(ctx)
+	if !ok {
+		backend = clerk.GetBackend()
+	}
 	return &Client{
-		Backend: clerk.GetBackend(),
+		Backend: backend,
 	}
 }
@@ -57,8 +57,8 @@ type CreateParams struct {
 }
 
 // Create creates a new user.
-func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.User, error) {
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+func (c *Client) Create(ctx context.Context, params *CreateParams, opts ...clerk.RequestOption) (*clerk.User, error) {
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.User{}
 	err := c.Backend.Call(ctx, req, resource)
@@ -66,12 +66,12 @@ func (c *Client) Create(ctx context.Context, params *CreateParams) (*clerk.User,
 }
 
 // Get retrieves details about the user.
-func (c *Client) Get(ctx context.Context, id string) (*clerk.User, error) {
+func (c *Client) Get(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -110,12 +110,12 @@ type UpdateParams struct {
 }
 
 // Update updates a user.
-func (c *Client) Update(ctx context.Context, id string, params *UpdateParams) (*clerk.User, error) {
+func (c *Client) Update(ctx context.Context, id string, params *UpdateParams, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -149,12 +149,12 @@ func (params *UpdateProfileImageParams) ToMultipart() ([]byte, string, error) {
 }
 
 // UpdateProfileImage sets or replaces the user's profile image.
-func (c *Client) UpdateProfileImage(ctx context.Context, id string, params *UpdateProfileImageParams) (*clerk.User, error) {
+func (c *Client) UpdateProfileImage(ctx context.Context, id string, params *UpdateProfileImageParams, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id, "/profile_image")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewMultipartAPIRequest(http.MethodPost, path)
+	req := clerk.NewMultipartAPIRequest(http.MethodPost, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -162,12 +162,12 @@ func (c *Client) UpdateProfileImage(ctx context.Context, id string, params *Upda
 }
 
 // DeleteProfileImage deletes the user's profile image.
-func (c *Client) DeleteProfileImage(ctx context.Context, id string) (*clerk.User, error) {
+func (c *Client) DeleteProfileImage(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id, "/profile_image")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -182,12 +182,12 @@ type UpdateMetadataParams struct {
 
 // UpdateMetadata updates the user's metadata by merging the
 // provided values with the existing ones.
-func (c *Client) UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams) (*clerk.User, error) {
+func (c *Client) UpdateMetadata(ctx context.Context, id string, params *UpdateMetadataParams, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id, "/metadata")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPatch, path)
+	req := clerk.NewAPIRequest(http.MethodPatch, path, opts...)
 	req.SetParams(params)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -195,12 +195,12 @@ func (c *Client) UpdateMetadata(ctx context.Context, id string, params *UpdateMe
 }
 
 // Delete deletes a user.
-func (c *Client) Delete(ctx context.Context, id string) (*clerk.DeletedResource, error) {
+func (c *Client) Delete(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, id)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -261,7 +261,7 @@ func (params *ListParams) ToQuery() url.Values {
 }
 
 // List returns a list of users.
-func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.UserList, error) {
+func (c *Client) List(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*clerk.UserList, error) {
 	// The Clerk API returns the results of GET /v1/users as an
 	// array. In order to build the final response that includes
 	// the total count, we need to make two API calls.
@@ -270,7 +270,7 @@ func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.UserList,
 	// The response is then synthesized from the individual responses.
 
 	// GET /v1/users
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	data := &userList{}
 	err := c.Backend.Call(ctx, req, data)
@@ -279,7 +279,7 @@ func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.UserList,
 	}
 
 	// GET /v1/users/count
-	totalCount, err := c.Count(ctx, params)
+	totalCount, err := c.Count(ctx, params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -291,13 +291,31 @@ func (c *Client) List(ctx context.Context, params *ListParams) (*clerk.UserList,
 	}, nil
 }
 
+// ListAll returns an iterator over all users that match the
+// provided params. Pages of results are fetched as the iterator
+// advances.
+func (c *Client) ListAll(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.User] {
+	if params == nil {
+		params = &ListParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.User, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.List(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Users, list.TotalCount, nil
+	})
+}
+
 // Count returns the total count of users satisfying the parameters.
-func (c *Client) Count(ctx context.Context, params *ListParams) (*TotalCount, error) {
+func (c *Client) Count(ctx context.Context, params *ListParams, opts ...clerk.RequestOption) (*TotalCount, error) {
 	path, err := clerk.JoinPath(path, "/count")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	resource := &TotalCount{}
 	err = c.Backend.Call(ctx, req, resource)
@@ -330,12 +348,12 @@ type ListOAuthAccessTokensParams struct {
 
 // ListOAuthAccessTokens retrieves a list of the user's access
 // tokens for a specific OAuth provider.
-func (c *Client) ListOAuthAccessTokens(ctx context.Context, params *ListOAuthAccessTokensParams) (*clerk.OAuthAccessTokenList, error) {
+func (c *Client) ListOAuthAccessTokens(ctx context.Context, params *ListOAuthAccessTokensParams, opts ...clerk.RequestOption) (*clerk.OAuthAccessTokenList, error) {
 	path, err := clerk.JoinPath(path, params.ID, "/oauth_access_tokens", fmt.Sprintf("%s?paginated=true", params.Provider))
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	list := &clerk.OAuthAccessTokenList{}
 	err = c.Backend.Call(ctx, req, list)
@@ -348,12 +366,12 @@ type DeleteMFAParams struct {
 }
 
 // DeleteMFA disables a user's multi-factor authentication methods.
-func (c *Client) DeleteMFA(ctx context.Context, params *DeleteMFAParams) (*MultifactorAuthentication, error) {
+func (c *Client) DeleteMFA(ctx context.Context, params *DeleteMFAParams, opts ...clerk.RequestOption) (*MultifactorAuthentication, error) {
 	path, err := clerk.JoinPath(path, params.ID, "/mfa")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &MultifactorAuthentication{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -365,48 +383,48 @@ type MultifactorAuthentication struct {
 }
 
 // Ban marks the user as banned.
-func (c *Client) Ban(ctx context.Context, id string) (*clerk.User, error) {
+func (c *Client) Ban(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id, "/ban")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // Unban removes the ban for a user.
-func (c *Client) Unban(ctx context.Context, id string) (*clerk.User, error) {
+func (c *Client) Unban(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id, "/unban")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // Lock marks the user as locked.
-func (c *Client) Lock(ctx context.Context, id string) (*clerk.User, error) {
+func (c *Client) Lock(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id, "/lock")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // Unlock removes the lock for a user.
-func (c *Client) Unlock(ctx context.Context, id string) (*clerk.User, error) {
+func (c *Client) Unlock(ctx context.Context, id string, opts ...clerk.RequestOption) (*clerk.User, error) {
 	path, err := clerk.JoinPath(path, id, "/unlock")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	resource := &clerk.User{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -423,18 +441,36 @@ func (params *ListOrganizationMembershipsParams) ToQuery() url.Values {
 }
 
 // ListOrganizationMemberships lists all the user's organization memberships.
-func (c *Client) ListOrganizationMemberships(ctx context.Context, id string, params *ListOrganizationMembershipsParams) (*clerk.OrganizationMembershipList, error) {
+func (c *Client) ListOrganizationMemberships(ctx context.Context, id string, params *ListOrganizationMembershipsParams, opts ...clerk.RequestOption) (*clerk.OrganizationMembershipList, error) {
 	path, err := clerk.JoinPath(path, id, "/organization_memberships")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	list := &clerk.OrganizationMembershipList{}
 	err = c.Backend.Call(ctx, req, list)
 	return list, err
 }
 
+// ListAllOrganizationMemberships returns an iterator over all the
+// user's organization memberships. Pages of results are fetched as
+// the iterator advances.
+func (c *Client) ListAllOrganizationMemberships(ctx context.Context, id string, params *ListOrganizationMembershipsParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationMembership] {
+	if params == nil {
+		params = &ListOrganizationMembershipsParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.OrganizationMembership, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.ListOrganizationMemberships(ctx, id, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.OrganizationMemberships, list.TotalCount, nil
+	})
+}
+
 type ListOrganizationInvitationsParams struct {
 	clerk.APIParams
 	clerk.ListParams
@@ -454,73 +490,91 @@ func (params *ListOrganizationInvitationsParams) ToQuery() url.Values {
 }
 
 // ListOrganizationInvitations lists all the user's organization invitations.
-func (c *Client) ListOrganizationInvitations(ctx context.Context, params *ListOrganizationInvitationsParams) (*clerk.OrganizationInvitationList, error) {
+func (c *Client) ListOrganizationInvitations(ctx context.Context, params *ListOrganizationInvitationsParams, opts ...clerk.RequestOption) (*clerk.OrganizationInvitationList, error) {
 	path, err := clerk.JoinPath(path, params.UserID, "/organization_invitations")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodGet, path)
+	req := clerk.NewAPIRequest(http.MethodGet, path, opts...)
 	req.SetParams(params)
 	list := &clerk.OrganizationInvitationList{}
 	err = c.Backend.Call(ctx, req, list)
 	return list, err
 }
 
+// ListAllOrganizationInvitations returns an iterator over all the
+// user's organization invitations that match the provided params.
+// Pages of results are fetched as the iterator advances.
+func (c *Client) ListAllOrganizationInvitations(ctx context.Context, params *ListOrganizationInvitationsParams, opts ...clerk.RequestOption) *clerk.Iterator[*clerk.OrganizationInvitation] {
+	if params == nil {
+		params = &ListOrganizationInvitationsParams{}
+	}
+	return clerk.NewIterator(ctx, params.ListParams, func(ctx context.Context, listParams clerk.ListParams) ([]*clerk.OrganizationInvitation, int64, error) {
+		pageParams := *params
+		pageParams.ListParams = listParams
+		list, err := c.ListOrganizationInvitations(ctx, &pageParams, opts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.OrganizationInvitations, list.TotalCount, nil
+	})
+}
+
 // DeletePasskey deletes a passkey by its identification ID.
-func (c *Client) DeletePasskey(ctx context.Context, userID, identificationID string) (*clerk.DeletedResource, error) {
+func (c *Client) DeletePasskey(ctx context.Context, userID, identificationID string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, userID, "/passkeys", identificationID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // DeleteWeb3Wallet deletes a web3 wallet by its identification ID.
-func (c *Client) DeleteWeb3Wallet(ctx context.Context, userID, identificationID string) (*clerk.DeletedResource, error) {
+func (c *Client) DeleteWeb3Wallet(ctx context.Context, userID, identificationID string, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, userID, "/web3_wallets", identificationID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // CreateTOTP creates a TOTP (Time-based One-Time Password) for the user.
-func (c *Client) CreateTOTP(ctx context.Context, userID string) (*clerk.TOTP, error) {
+func (c *Client) CreateTOTP(ctx context.Context, userID string, opts ...clerk.RequestOption) (*clerk.TOTP, error) {
 	path, err := clerk.JoinPath(path, userID, "/totp")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodPost, path)
+	req := clerk.NewAPIRequest(http.MethodPost, path, opts...)
 	resource := &clerk.TOTP{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // DeleteTOTP deletes all the TOTPs from a given user.
-func (c *Client) DeleteTOTP(ctx context.Context, userID string) (*MultifactorAuthentication, error) {
+func (c *Client) DeleteTOTP(ctx context.Context, userID string, opts ...clerk.RequestOption) (*MultifactorAuthentication, error) {
 	path, err := clerk.JoinPath(path, userID, "/totp")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &MultifactorAuthentication{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
 }
 
 // DeleteBackupCode deletes all the backup codes from a given user.
-func (c *Client) DeleteBackupCode(ctx context.Context, userID string) (*MultifactorAuthentication, error) {
+func (c *Client) DeleteBackupCode(ctx context.Context, userID string, opts ...clerk.RequestOption) (*MultifactorAuthentication, error) {
 	path, err := clerk.JoinPath(path, userID, "/backup_code")
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &MultifactorAuthentication{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -533,12 +587,12 @@ type DeleteExternalAccountParams struct {
 }
 
 // DeleteExternalAccount deletes an external account by its ID.
-func (c *Client) DeleteExternalAccount(ctx context.Context, params *DeleteExternalAccountParams) (*clerk.DeletedResource, error) {
+func (c *Client) DeleteExternalAccount(ctx context.Context, params *DeleteExternalAccountParams, opts ...clerk.RequestOption) (*clerk.DeletedResource, error) {
 	path, err := clerk.JoinPath(path, params.UserID, "/external_accounts", params.ID)
 	if err != nil {
 		return nil, err
 	}
-	req := clerk.NewAPIRequest(http.MethodDelete, path)
+	req := clerk.NewAPIRequest(http.MethodDelete, path, opts...)
 	resource := &clerk.DeletedResource{}
 	err = c.Backend.Call(ctx, req, resource)
 	return resource, err
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/clerk/clerk-sdk-go/v2"
@@ -123,6 +124,46 @@ func TestUserClientCount(t *testing.T) {
 	require.Equal(t, int64(10), totalCount.TotalCount)
 }
 
+func TestUserClientListAll(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var listOffsets []string
+	countRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, []string{"foo@bar.com"}, r.URL.Query()["email_address"])
+		require.Equal(t, "1", r.URL.Query().Get("limit"))
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/v1/users/count" {
+			countRequests++
+			_, err := w.Write([]byte(`{"object":"total_count","total_count":2}`))
+			require.NoError(t, err)
+			return
+		}
+		require.Equal(t, "/v1/users", r.URL.Path)
+		offset := r.URL.Query().Get("offset")
+		listOffsets = append(listOffsets, offset)
+		_, err := w.Write([]byte(fmt.Sprintf(`[{"object":"user","id":"user_%s"}]`, offset)))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	config := &clerk.ClientConfig{}
+	config.URL = clerk.String(ts.URL + "/v1")
+	config.HTTPClient = ts.Client()
+	client := NewClient(config)
+	params := &ListParams{EmailAddresses: []string{"foo@bar.com"}}
+	params.Limit = clerk.Int64(1)
+	users, err := client.ListAll(context.Background(), params).All()
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, "user_0", users[0].ID)
+	require.Equal(t, "user_1", users[1].ID)
+	// Each page is a list and a count request.
+	require.ElementsMatch(t, []string{"0", "1"}, listOffsets)
+	require.Equal(t, 2, countRequests)
+}
+
 func TestUserClientGet(t *testing.T) {
 	t.Parallel()
 	id := "user_123"
@@ -423,6 +464,30 @@ func TestUserClientListOrganizationMemberships(t *testing.T) {
 	require.Equal(t, userID, list.OrganizationMemberships[0].PublicUserData.UserID)
 }
 
+func TestUserClientListAllOrganizationMemberships(t *testing.T) {
+	t.Parallel()
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = &http.Client{
+		Transport: &clerktest.RoundTripper{
+			T:      t,
+			Out:    json.RawMessage(`{"data":[{"id":"orgmem_123"}],"total_count":1}`),
+			Method: http.MethodGet,
+			Path:   "/v1/users/user_123/organization_memberships",
+			Query: &url.Values{
+				"limit":  []string{"10"},
+				"offset": []string{"0"},
+			},
+		},
+	}
+	client := NewClient(config)
+	params := &ListOrganizationMembershipsParams{}
+	params.Limit = clerk.Int64(10)
+	memberships, err := client.ListAllOrganizationMemberships(context.Background(), "user_123", params).All()
+	require.NoError(t, err)
+	require.Len(t, memberships, 1)
+	require.Equal(t, "orgmem_123", memberships[0].ID)
+}
+
 func TestUserClientListOrganizationInvitations(t *testing.T) {
 	t.Parallel()
 	invitationID := "orginv_123"
@@ -462,6 +527,34 @@ func TestUserClientListOrganizationInvitations(t *testing.T) {
 	require.Equal(t, organizationID, list.OrganizationInvitations[0].OrganizationID)
 }
 
+func TestUserClientListAllOrganizationInvitations(t *testing.T) {
+	t.Parallel()
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = &http.Client{
+		Transport: &clerktest.RoundTripper{
+			T:      t,
+			Out:    json.RawMessage(`{"data":[{"id":"orginv_123"}],"total_count":1}`),
+			Method: http.MethodGet,
+			Path:   "/v1/users/user_123/organization_invitations",
+			Query: &url.Values{
+				"limit":  []string{"10"},
+				"offset": []string{"0"},
+				"status": []string{"pending"},
+			},
+		},
+	}
+	client := NewClient(config)
+	params := &ListOrganizationInvitationsParams{
+		UserID:   "user_123",
+		Statuses: &[]string{"pending"},
+	}
+	params.Limit = clerk.Int64(10)
+	invitations, err := client.ListAllOrganizationInvitations(context.Background(), params).All()
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	require.Equal(t, "orginv_123", invitations[0].ID)
+}
+
 func TestUserClientDeletePasskey(t *testing.T) {
 	t.Parallel()
 	userID := "user_123"
@@ -578,3 +671,52 @@ func TestUserClientDeleteExternalAccount(t *testing.T) {
 	require.Equal(t, externalAccountID, externalAccount.ID)
 	require.Equal(t, "external_account", externalAccount.Object)
 }
+
+func TestUserClientGet_RequestOptions(t *testing.T) {
+	t.Parallel()
+	id := "user_123"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/users/"+id, r.URL.Path)
+		require.Equal(t, "Bearer sk_test_tenant", r.Header.Get("Authorization"))
+		require.Equal(t, "tenant", r.Header.Get("X-Tenant"))
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":"%s"}`, id)))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	config := &clerk.ClientConfig{}
+	config.HTTPClient = ts.Client()
+	config.URL = clerk.String(ts.URL + "/v1")
+	config.Key = clerk.String("sk_test_default")
+	client := NewClient(config)
+	user, err := client.Get(
+		context.Background(),
+		id,
+		clerk.WithSecretKey("sk_test_tenant"),
+		clerk.WithHeader("X-Tenant", "tenant"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, id, user.ID)
+}
+
+func TestGet_ContextBackend(t *testing.T) {
+	t.Parallel()
+	id := "user_123"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/users/"+id, r.URL.Path)
+		require.Equal(t, "Bearer sk_test_tenant", r.Header.Get("Authorization"))
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id":"%s"}`, id)))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	// The package level function uses the Backend from the context.
+	ctx := clerk.ContextWithBackend(context.Background(), clerk.NewBackend(&clerk.BackendConfig{
+		HTTPClient: ts.Client(),
+		URL:        clerk.String(ts.URL + "/v1"),
+		Key:        clerk.String("sk_test_tenant"),
+	}))
+	user, err := Get(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, id, user.ID)
+}